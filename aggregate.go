@@ -0,0 +1,187 @@
+package sqrl
+
+import "bytes"
+
+// AggregateBuilder builds aggregate function calls like SUM(amount).
+type AggregateBuilder struct {
+	function    string
+	operands    []interface{}
+	distinct    bool
+	orderBys    []Sqlizer
+	withinGroup []Sqlizer
+	filterParts []Sqlizer
+}
+
+// Aggregate returns a new AggregateBuilder for a call of aggregate function
+// with given operands. Strings are used verbatim, Sqlizers are rendered with
+// their args merged and other values are bound as args, see Coalesce.
+//
+// Ex:
+//     Aggregate("string_agg", "name", Lit(",")) // string_agg(name, ?)
+func Aggregate(function string, operands ...interface{}) *AggregateBuilder {
+	return &AggregateBuilder{function: function, operands: operands}
+}
+
+// Sum returns a new AggregateBuilder for SUM(operand).
+//
+// Ex:
+//     Select().Column(Sum("amount").As("total")).From("payments")
+//     // SELECT SUM(amount) AS total FROM payments
+func Sum(operand interface{}) *AggregateBuilder {
+	return Aggregate("SUM", operand)
+}
+
+// Avg returns a new AggregateBuilder for AVG(operand).
+func Avg(operand interface{}) *AggregateBuilder {
+	return Aggregate("AVG", operand)
+}
+
+// Min returns a new AggregateBuilder for MIN(operand).
+func Min(operand interface{}) *AggregateBuilder {
+	return Aggregate("MIN", operand)
+}
+
+// Max returns a new AggregateBuilder for MAX(operand).
+func Max(operand interface{}) *AggregateBuilder {
+	return Aggregate("MAX", operand)
+}
+
+// Count returns a new AggregateBuilder for COUNT(operand), e.g. Count("*").
+func Count(operand interface{}) *AggregateBuilder {
+	return Aggregate("COUNT", operand)
+}
+
+// CountDistinct returns a new AggregateBuilder for COUNT(DISTINCT operand).
+func CountDistinct(operand interface{}) *AggregateBuilder {
+	return Count(operand).Distinct()
+}
+
+// ToSql implements Sqlizer
+func (b *AggregateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sql := &bytes.Buffer{}
+
+	sql.WriteString(b.function)
+	sql.WriteString("(")
+	if b.distinct {
+		sql.WriteString("DISTINCT ")
+	}
+	for i, operand := range b.operands {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+
+		var operandSql string
+		var operandArgs []interface{}
+		operandSql, operandArgs, err = operandToSql(operand)
+		if err != nil {
+			return
+		}
+		sql.WriteString(operandSql)
+		args = append(args, operandArgs...)
+	}
+	args, err = appendClauseToSql(b.orderBys, sql, " ORDER BY ", ", ", args)
+	if err != nil {
+		return
+	}
+	sql.WriteString(")")
+
+	if len(b.withinGroup) > 0 {
+		group := &bytes.Buffer{}
+		args, err = appendToSql(b.withinGroup, group, ", ", args)
+		if err != nil {
+			return
+		}
+		if group.Len() > 0 {
+			sql.WriteString(" WITHIN GROUP (ORDER BY ")
+			group.WriteTo(sql)
+			sql.WriteString(")")
+		}
+	}
+
+	if len(b.filterParts) > 0 {
+		filter := &bytes.Buffer{}
+		args, err = appendToSql(b.filterParts, filter, " AND ", args)
+		if err != nil {
+			return
+		}
+		if filter.Len() > 0 {
+			sql.WriteString(" FILTER (WHERE ")
+			filter.WriteTo(sql)
+			sql.WriteString(")")
+		}
+	}
+
+	return sql.String(), args, nil
+}
+
+// Distinct makes the function aggregate only distinct values,
+// e.g. COUNT(DISTINCT user_id).
+func (b *AggregateBuilder) Distinct() *AggregateBuilder {
+	b.distinct = true
+	return b
+}
+
+// OrderBy adds ORDER BY expressions sorting values of ordered aggregates.
+//
+// Ex:
+//     Aggregate("string_agg", "name", "','").OrderBy("name") // string_agg(name, ',' ORDER BY name)
+func (b *AggregateBuilder) OrderBy(orderBys ...string) *AggregateBuilder {
+	for _, orderBy := range orderBys {
+		b.orderBys = append(b.orderBys, newPart(orderBy))
+	}
+	return b
+}
+
+// OrderByClause adds an ORDER BY expression with bound args sorting values
+// of ordered aggregates.
+func (b *AggregateBuilder) OrderByClause(pred interface{}, args ...interface{}) *AggregateBuilder {
+	b.orderBys = append(b.orderBys, newPart(pred, args...))
+	return b
+}
+
+// WithinGroup adds WITHIN GROUP (ORDER BY ...) clause of ordered-set
+// aggregates. Args of the function operands precede args of the clause.
+//
+// Ex:
+//     Aggregate("percentile_cont", 0.5).WithinGroup("val") // percentile_cont(?) WITHIN GROUP (ORDER BY val)
+func (b *AggregateBuilder) WithinGroup(orderBys ...string) *AggregateBuilder {
+	for _, orderBy := range orderBys {
+		b.withinGroup = append(b.withinGroup, newPart(orderBy))
+	}
+	return b
+}
+
+// Filter adds a FILTER (WHERE ...) clause restricting rows aggregated by
+// the function. Predicates are the same as of SelectBuilder.Where, multiple
+// predicates are joined with AND.
+//
+// Ex:
+//     Count("*").Filter("status = ?", "active") // COUNT(*) FILTER (WHERE status = ?)
+//
+// FILTER clause is supported by PostgreSQL and SQLite
+func (b *AggregateBuilder) Filter(pred interface{}, args ...interface{}) *AggregateBuilder {
+	b.filterParts = append(b.filterParts, newWherePart(pred, args...))
+	return b
+}
+
+// As returns the aggregate with alias for column in SelectBuilder. Unlike
+// As function, the aggregate is not wrapped in parentheses.
+//
+// Ex:
+//     Count("*").As("total") // COUNT(*) AS total
+func (b *AggregateBuilder) As(alias string) Sqlizer {
+	return aggregateAlias{aggregate: b, alias: alias}
+}
+
+type aggregateAlias struct {
+	aggregate *AggregateBuilder
+	alias     string
+}
+
+func (a aggregateAlias) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = a.aggregate.ToSql()
+	if err != nil {
+		return
+	}
+	return sql + " AS " + a.alias, args, nil
+}
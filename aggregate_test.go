@@ -0,0 +1,98 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregates(t *testing.T) {
+	sql, args, err := Select().
+		Column(Sum("amount").As("total")).
+		Column(Avg(Expr("amount * ?", 2)).As("avg_double")).
+		Column(Min("created_at")).
+		Column(Max("created_at")).
+		Column(Count("*")).
+		Column(CountDistinct("user_id")).
+		From("payments").
+		Where("status = ?", "paid").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT SUM(amount) AS total, AVG(amount * $1) AS avg_double, " +
+		"MIN(created_at), MAX(created_at), COUNT(*), COUNT(DISTINCT user_id) " +
+		"FROM payments WHERE status = $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{2, "paid"}, args)
+}
+
+func TestAggregate(t *testing.T) {
+	sql, args, err := Aggregate("string_agg", "name", Lit(",")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "string_agg(name, ?)", sql)
+	assert.Equal(t, []interface{}{","}, args)
+}
+
+func TestAggregateFilter(t *testing.T) {
+	sql, args, err := Count("*").Filter("status = ?", "active").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COUNT(*) FILTER (WHERE status = ?)", sql)
+	assert.Equal(t, []interface{}{"active"}, args)
+
+	sql, args, err = Select("user_id").
+		Column(Sum(Expr("amount * ?", 100)).Filter(Eq{"currency": "EUR"}).Filter("amount > ?", 0).As("eur_cents")).
+		Column(Count("*").Filter(EqOptional{"status": nil}).As("all_orders")).
+		From("orders").
+		Where("created_at > ?", "2020-01-01").
+		GroupBy("user_id").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT user_id, SUM(amount * $1) FILTER (WHERE currency = $2 AND amount > $3) AS eur_cents, " +
+		"COUNT(*) AS all_orders FROM orders WHERE created_at > $4 GROUP BY user_id"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{100, "EUR", 0, "2020-01-01"}, args)
+}
+
+func TestAggregateDistinctOrderBy(t *testing.T) {
+	sql, _, err := Count("user_id").Distinct().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COUNT(DISTINCT user_id)", sql)
+
+	sql, _, err = Aggregate("string_agg", "name", "','").OrderBy("name").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "string_agg(name, ',' ORDER BY name)", sql)
+
+	sql, args, err := Aggregate("array_agg", "tag").
+		Distinct().
+		OrderBy("tag").
+		OrderByClause("tag = ? DESC", "featured").
+		Filter("tag <> ?", "").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "array_agg(DISTINCT tag ORDER BY tag, tag = ? DESC) FILTER (WHERE tag <> ?)", sql)
+	assert.Equal(t, []interface{}{"featured", ""}, args)
+}
+
+func TestAggregateWithinGroup(t *testing.T) {
+	sql, args, err := Aggregate("percentile_cont", 0.5).WithinGroup("val").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "percentile_cont(?) WITHIN GROUP (ORDER BY val)", sql)
+	assert.Equal(t, []interface{}{0.5}, args)
+
+	sql, args, err = Select("region").
+		Column(Aggregate("percentile_disc", 0.9).WithinGroup("latency DESC", "id").Filter("status = ?", 200).As("p90")).
+		From("requests").
+		Where("day = ?", "2020-01-01").
+		GroupBy("region").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT region, percentile_disc($1) WITHIN GROUP (ORDER BY latency DESC, id) " +
+		"FILTER (WHERE status = $2) AS p90 FROM requests WHERE day = $3 GROUP BY region"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{0.9, 200, "2020-01-01"}, args)
+}
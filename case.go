@@ -99,9 +99,35 @@ func (b *CaseBuilder) When(when interface{}, then interface{}) *CaseBuilder {
 	return b
 }
 
+// WhenValue adds "WHEN ? THEN ?" part to CASE construct, binding value and
+// result as args (Sqlizer values are rendered in place). Useful for simple CASE
+// comparing values against an operand set with Case(operand).
+//
+// Ex:
+//     Case("status").WhenValue("a", 1).WhenValue("b", 2)
+//     // CASE status WHEN ? THEN ? WHEN ? THEN ? END
+func (b *CaseBuilder) WhenValue(value interface{}, result interface{}) *CaseBuilder {
+	b.whenParts = append(b.whenParts, whenPart{valuePart(value), valuePart(result)})
+	return b
+}
+
 // Else sets optional "ELSE ..." part for CASE construct
 func (b *CaseBuilder) Else(expr interface{}) *CaseBuilder {
 	b.elsePart = newPart(expr)
 	return b
 
 }
+
+// ElseValue sets optional "ELSE ?" part for CASE construct, binding value as an arg.
+func (b *CaseBuilder) ElseValue(value interface{}) *CaseBuilder {
+	b.elsePart = valuePart(value)
+	return b
+}
+
+// valuePart binds value as an arg, Sqlizer values are rendered in place
+func valuePart(value interface{}) Sqlizer {
+	if s, ok := value.(Sqlizer); ok {
+		return newPart(s)
+	}
+	return newPart("?", value)
+}
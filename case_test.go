@@ -139,3 +139,60 @@ func TestCaseWithNoWhenClause(t *testing.T) {
 
 	assert.Equal(t, "case expression must contain at lease one WHEN clause", err.Error())
 }
+
+func TestCaseNestedElse(t *testing.T) {
+	nested := Case().
+		When(Expr("score > ?", 50), Expr("?", "average")).
+		Else(Expr("?", "poor"))
+
+	caseStmt := Case().
+		When(Expr("score > ?", 90), Expr("?", "excellent")).
+		When(Expr("score > ?", 75), Expr("UPPER(?)", "good")).
+		Else(nested)
+
+	sql, args, err := Select().
+		Column(Alias(caseStmt, "grade")).
+		From("results").
+		Where("year = ?", 2020).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT (CASE " +
+		"WHEN score > $1 THEN $2 " +
+		"WHEN score > $3 THEN UPPER($4) " +
+		"ELSE CASE WHEN score > $5 THEN $6 ELSE $7 END " +
+		"END) AS grade " +
+		"FROM results WHERE year = $8"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{90, "excellent", 75, "good", 50, "average", "poor", 2020}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestCaseWhenValue(t *testing.T) {
+	caseStmt := Case("status").
+		WhenValue("a", 1).
+		ElseValue(0)
+
+	sql, args, err := caseStmt.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CASE status WHEN ? THEN ? ELSE ? END", sql)
+	assert.Equal(t, []interface{}{"a", 1, 0}, args)
+
+	sql, args, err = Select().
+		Column(Alias(Case("status").WhenValue("a", "active").WhenValue("b", "blocked"), "label")).
+		From("users").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT (CASE status WHEN $1 THEN $2 WHEN $3 THEN $4 END) AS label FROM users", sql)
+	assert.Equal(t, []interface{}{"a", "active", "b", "blocked"}, args)
+}
+
+func TestCaseWhenValueSqlizer(t *testing.T) {
+	sql, args, err := Case("x").WhenValue(Expr("y % 2"), 1).ElseValue(Expr("z % ?", 3)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CASE x WHEN y % 2 THEN ? ELSE z % ? END", sql)
+	assert.Equal(t, []interface{}{1, 3}, args)
+}
@@ -0,0 +1,137 @@
+package sqrl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CreateTableAsBuilder builds SQL CREATE TABLE ... AS SELECT statements.
+type CreateTableAsBuilder struct {
+	StatementBuilderType
+
+	table     string
+	temporary bool
+	query     *SelectBuilder
+	withData  string
+}
+
+// NewCreateTableAsBuilder creates new instance of CreateTableAsBuilder
+func NewCreateTableAsBuilder(b StatementBuilderType) *CreateTableAsBuilder {
+	return &CreateTableAsBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *CreateTableAsBuilder) RunWith(runner BaseRunner) *CreateTableAsBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *CreateTableAsBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *CreateTableAsBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *CreateTableAsBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(b.runContext())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *CreateTableAsBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
+// query. Placeholder format of the nested query is ignored.
+func (b *CreateTableAsBuilder) PlaceholderFormat(f PlaceholderFormat) *CreateTableAsBuilder {
+	b.placeholderFormat = f
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *CreateTableAsBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *CreateTableAsBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
+	if len(b.table) == 0 {
+		err = fmt.Errorf("create table statements must specify a table")
+		return
+	}
+	if b.query == nil {
+		err = fmt.Errorf("create table as statements must have a query")
+		return
+	}
+
+	sql := &bytes.Buffer{}
+
+	sql.WriteString("CREATE ")
+	if b.temporary {
+		sql.WriteString("TEMP ")
+	}
+	sql.WriteString("TABLE ")
+	sql.WriteString(b.table)
+	sql.WriteString(" AS ")
+
+	querySql, queryArgs, err := nestedToSql(b.query)
+	if err != nil {
+		return
+	}
+	sql.WriteString(querySql)
+	args = append(args, queryArgs...)
+
+	if len(b.withData) > 0 {
+		sql.WriteString(" ")
+		sql.WriteString(b.withData)
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Table sets the name of the created table.
+func (b *CreateTableAsBuilder) Table(table string) *CreateTableAsBuilder {
+	b.table = table
+	return b
+}
+
+// As sets the query providing columns and rows of the created table.
+func (b *CreateTableAsBuilder) As(query *SelectBuilder) *CreateTableAsBuilder {
+	b.query = query
+	return b
+}
+
+// Temporary makes the statement create a temporary table dropped at the end
+// of the session.
+func (b *CreateTableAsBuilder) Temporary() *CreateTableAsBuilder {
+	b.temporary = true
+	return b
+}
+
+// WithData adds WITH DATA option filling the created table with rows of
+// the query, which is the default.
+func (b *CreateTableAsBuilder) WithData() *CreateTableAsBuilder {
+	b.withData = "WITH DATA"
+	return b
+}
+
+// WithNoData adds WITH NO DATA option creating the table with columns of
+// the query, but without any rows.
+func (b *CreateTableAsBuilder) WithNoData() *CreateTableAsBuilder {
+	b.withData = "WITH NO DATA"
+	return b
+}
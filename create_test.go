@@ -0,0 +1,54 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTableAsToSql(t *testing.T) {
+	query := Select("user_id", "SUM(amount) AS total").
+		From("payments").
+		Where("paid_at > ?", "2020-01-01").
+		GroupBy("user_id").
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := CreateTableAs("report", query).Temporary().WithData().ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "CREATE TEMP TABLE report AS " +
+		"SELECT user_id, SUM(amount) AS total FROM payments WHERE paid_at > ? GROUP BY user_id " +
+		"WITH DATA"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"2020-01-01"}, args)
+
+	sql, args, err = CreateTableAs("report", query).WithNoData().PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql = "CREATE TABLE report AS " +
+		"SELECT user_id, SUM(amount) AS total FROM payments WHERE paid_at > $1 GROUP BY user_id " +
+		"WITH NO DATA"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"2020-01-01"}, args)
+}
+
+func TestCreateTableAsToSqlErr(t *testing.T) {
+	_, _, err := CreateTableAs("", Select("1")).ToSql()
+	assert.EqualError(t, err, "create table statements must specify a table")
+
+	_, _, err = CreateTableAs("report", nil).ToSql()
+	assert.EqualError(t, err, "create table as statements must have a query")
+
+	_, _, err = CreateTableAs("report", Select()).ToSql()
+	assert.EqualError(t, err, "select statements must have at least one result column")
+}
+
+func TestCreateTableAsRunners(t *testing.T) {
+	db := &DBStub{}
+	_, err := CreateTableAs("t", Select("*").From("s")).RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE t AS SELECT * FROM s", db.LastExecSql)
+
+	_, err = CreateTableAs("t", Select("*").From("s")).Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,113 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Debug builds the query and interpolates its args into the SQL string.
+//
+// The result is meant for logging and debugging only, it is not escaped
+// properly for every database and must never be executed.
+// Placeholder format of s is ignored, args are put in place of question marks.
+//
+// Ex:
+//     Debug(Select("*").From("users").Where(Eq{"name": "O'Neil"}))
+//     // SELECT * FROM users WHERE name = 'O''Neil'
+func Debug(s Sqlizer) string {
+	sqlStr, args, err := nestedToSql(s)
+	if err != nil {
+		return fmt.Sprintf("[ToSql error: %v]", err)
+	}
+
+	i := 0
+	sqlStr, err = replacePlaceholders(sqlStr, func(buf *bytes.Buffer, n int) error {
+		if n > len(args) {
+			return fmt.Errorf("not enough args for placeholder %d, got %d", n, len(args))
+		}
+		i = n
+		buf.WriteString(literal(args[n-1]))
+		return nil
+	})
+	if err != nil {
+		return fmt.Sprintf("[Debug error: %v]", err)
+	}
+	if i != len(args) {
+		return fmt.Sprintf("[Debug error: got %d args for %d placeholders]", len(args), i)
+	}
+	return sqlStr
+}
+
+// literal formats arg as SQL literal, values not supported by database/sql
+// are formatted as strings and errors are formatted in place
+func literal(arg interface{}) string {
+	lit, err := literalValue(arg)
+	if unsupported, ok := err.(unsupportedLiteralError); ok {
+		return quoteLiteral(fmt.Sprint(unsupported.value))
+	}
+	if err != nil {
+		return fmt.Sprintf("[Value error: %v]", err)
+	}
+	return lit
+}
+
+// unsupportedLiteralError is returned by literalValue for values which
+// cannot be converted to driver.Value
+type unsupportedLiteralError struct {
+	value interface{}
+	err   error
+}
+
+func (e unsupportedLiteralError) Error() string {
+	return fmt.Sprintf("cannot format %T as literal: %v", e.value, e.err)
+}
+
+// literalValue formats arg as SQL literal. Args are converted like by
+// database/sql, so pointers are dereferenced and nil pointers are NULL.
+func literalValue(arg interface{}) (string, error) {
+	if named, ok := arg.(sql.NamedArg); ok {
+		arg = named.Value
+	}
+	if valuer, ok := arg.(driver.Valuer); ok && !isNilValue(reflect.ValueOf(arg)) {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		arg = v
+	}
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(arg)
+	if err != nil {
+		return "", unsupportedLiteralError{value: arg, err: err}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case string:
+		return quoteLiteral(v), nil
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'", nil
+	case time.Time:
+		return quoteLiteral(v.Format(time.RFC3339)), nil
+	default:
+		return "", unsupportedLiteralError{value: arg, err: fmt.Errorf("unsupported type %T", v)}
+	}
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
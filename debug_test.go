@@ -0,0 +1,45 @@
+package sqrl
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebug(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := Select("*").
+		From("users").
+		Where("name = ? AND age > ? AND deleted_at IS ? AND created_at < ?", "O'Neil", 18, nil, created).
+		Where("avatar = ? AND active = ?", []byte{0xca, 0xfe}, true).
+		Where("nick = ?", sql.NullString{String: "bob", Valid: true}).
+		PlaceholderFormat(Dollar)
+
+	expectedSql := "SELECT * FROM users " +
+		"WHERE name = 'O''Neil' AND age > 18 AND deleted_at IS NULL AND created_at < '2020-01-02T03:04:05Z' " +
+		"AND avatar = X'cafe' AND active = TRUE AND nick = 'bob'"
+	assert.Equal(t, expectedSql, Debug(b))
+}
+
+func TestDebugNested(t *testing.T) {
+	b := Update("users").
+		Set("name", "foo").
+		Where(Expr("id IN (?)", Select("id").From("admins").Where("level > ?", 2))).
+		PlaceholderFormat(Dollar)
+
+	assert.Equal(t, "UPDATE users SET name = 'foo' WHERE id IN (SELECT id FROM admins WHERE level > 2)", Debug(b))
+}
+
+func TestDebugValues(t *testing.T) {
+	x := 5
+	var none *int
+	assert.Equal(t, "a = 5 AND b IS NULL AND c = '[1 2]'", Debug(Expr("a = ? AND b IS ? AND c = ?", &x, none, []int{1, 2})))
+}
+
+func TestDebugErrors(t *testing.T) {
+	assert.Equal(t, "[ToSql error: select statements must have at least one result column]", Debug(Select()))
+	assert.Equal(t, "[Debug error: got 2 args for 1 placeholders]", Debug(Expr("a = ?", 1, 2)))
+	assert.Equal(t, "[Debug error: not enough args for placeholder 2, got 1]", Debug(Expr("a = ? AND b = ?", 1)))
+}
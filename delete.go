@@ -17,10 +17,10 @@ type DeleteBuilder struct {
 
 	returning
 
-	prefixes   exprs
+	prefixes   []Sqlizer
 	what       []string
 	from       string
-	joins      []string
+	joins      []Sqlizer
 	usingParts []Sqlizer
 	whereParts []Sqlizer
 	orderBys   []string
@@ -30,7 +30,8 @@ type DeleteBuilder struct {
 	offset      uint64
 	offsetValid bool
 
-	suffixes exprs
+	suffixes   []Sqlizer
+	suffixArgs []interface{}
 }
 
 // NewDeleteBuilder creates new instance of DeleteBuilder
@@ -38,15 +39,41 @@ func NewDeleteBuilder(b StatementBuilderType) *DeleteBuilder {
 	return &DeleteBuilder{StatementBuilderType: b}
 }
 
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+//
+// Clauses are copied, but their args and nested builders (e.g. subqueries)
+// are shared between the original and the copy.
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := *b
+	c.returning = append(returning(nil), b.returning...)
+	c.prefixes = append([]Sqlizer(nil), b.prefixes...)
+	c.what = append([]string(nil), b.what...)
+	c.joins = append([]Sqlizer(nil), b.joins...)
+	c.usingParts = append([]Sqlizer(nil), b.usingParts...)
+	c.whereParts = append([]Sqlizer(nil), b.whereParts...)
+	c.orderBys = append([]string(nil), b.orderBys...)
+	c.suffixes = append([]Sqlizer(nil), b.suffixes...)
+	c.suffixArgs = append([]interface{}(nil), b.suffixArgs...)
+	return &c
+}
+
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b *DeleteBuilder) RunWith(runner BaseRunner) *DeleteBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
 
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *DeleteBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *DeleteBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b *DeleteBuilder) Exec() (sql.Result, error) {
-	return b.ExecContext(context.Background())
+	return b.ExecContext(b.runContext())
 }
 
 // ExecContext builds and Execs the query with the Runner set by RunWith using given context.
@@ -59,7 +86,7 @@ func (b *DeleteBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *DeleteBuilder) Query() (RowsScanner, error) {
-	return b.QueryContext(context.Background())
+	return b.QueryContext(b.runContext())
 }
 
 // QueryContext builds and runs the query using given context and Query command.
@@ -72,7 +99,7 @@ func (b *DeleteBuilder) QueryContext(ctx context.Context) (RowsScanner, error) {
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b *DeleteBuilder) QueryRow() RowScanner {
-	return b.QueryRowContext(context.Background())
+	return b.QueryRowContext(b.runContext())
 }
 
 // QueryRowContext builds and runs the query using given context.
@@ -101,15 +128,33 @@ func (b *DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) *DeleteBuilder {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *DeleteBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
 	if len(b.from) == 0 {
 		err = fmt.Errorf("delete statements must specify a From table")
 		return
 	}
+	if b.fetchSyntax && (b.limitValid || b.offsetValid) {
+		err = fmt.Errorf("delete statements cannot have LIMIT or OFFSET with OFFSET ... FETCH syntax")
+		return
+	}
 
 	sql := &bytes.Buffer{}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.prefixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
@@ -126,7 +171,10 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.joins) > 0 {
 		sql.WriteString(" ")
-		sql.WriteString(strings.Join(b.joins, " "))
+		args, err = appendToSql(b.joins, sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(b.usingParts) > 0 {
@@ -138,8 +186,7 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.whereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(b.whereParts, sql, " AND ", args)
+		args, err = appendClauseToSql(b.whereParts, sql, " WHERE ", " AND ", args)
 		if err != nil {
 			return
 		}
@@ -150,7 +197,6 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(strings.Join(b.orderBys, ", "))
 	}
 
-	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
 	if b.limitValid {
 		sql.WriteString(" LIMIT ")
 		sql.WriteString(strconv.FormatUint(b.limit, 10))
@@ -162,6 +208,9 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.returning) > 0 {
+		if err = b.checkPostgresClause("RETURNING"); err != nil {
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -170,10 +219,14 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.suffixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
+	args = append(args, b.suffixArgs...)
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	sqlStr = sql.String()
 	return
 }
 
@@ -183,6 +236,13 @@ func (b *DeleteBuilder) Prefix(sql string, args ...interface{}) *DeleteBuilder {
 	return b
 }
 
+// PrefixExpr adds an expression built by expr to the beginning of the query.
+// Its args are placed before args of the query.
+func (b *DeleteBuilder) PrefixExpr(expr Sqlizer) *DeleteBuilder {
+	b.prefixes = append(b.prefixes, newPart(expr))
+	return b
+}
+
 // From sets the FROM clause of the query.
 func (b *DeleteBuilder) From(from string) *DeleteBuilder {
 	b.from = from
@@ -269,29 +329,58 @@ func (b *DeleteBuilder) ReturningSelect(from *SelectBuilder, alias string) *Dele
 	return b
 }
 
+// ReturningExpr adds an expression with bound args to RETURNING clause of the query
+//
+// DELETE ... RETURNING is PostgreSQL specific extension
+func (b *DeleteBuilder) ReturningExpr(expr interface{}, args ...interface{}) *DeleteBuilder {
+	b.returning.ReturningExpr(expr, args...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *DeleteBuilder) Suffix(sql string, args ...interface{}) *DeleteBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
 	return b
 }
 
+// SuffixExpr adds an expression built by expr to the end of the query.
+// Its args are placed after args of the query.
+func (b *DeleteBuilder) SuffixExpr(expr Sqlizer) *DeleteBuilder {
+	b.suffixes = append(b.suffixes, newPart(expr))
+	return b
+}
+
+// SuffixArgs appends args to the end of the query args without adding any SQL.
+// It is a low-level escape hatch for placeholders of a suffix composed in
+// pieces, args are placed after args of all other clauses including suffixes.
+func (b *DeleteBuilder) SuffixArgs(args ...interface{}) *DeleteBuilder {
+	b.suffixArgs = append(b.suffixArgs, args...)
+	return b
+}
+
 // JoinClause adds a join clause to the query.
-func (b *DeleteBuilder) JoinClause(join string) *DeleteBuilder {
-	b.joins = append(b.joins, join)
+func (b *DeleteBuilder) JoinClause(pred interface{}, args ...interface{}) *DeleteBuilder {
+	b.joins = append(b.joins, newPart(pred, args...))
 	return b
 }
 
 // Join adds a JOIN clause to the query.
-func (b *DeleteBuilder) Join(join string) *DeleteBuilder {
-	return b.JoinClause("JOIN " + join)
+//
+// DELETE ... JOIN is an MySQL specific extension
+func (b *DeleteBuilder) Join(join string, rest ...interface{}) *DeleteBuilder {
+	return b.JoinClause("JOIN "+join, rest...)
 }
 
 // LeftJoin adds a LEFT JOIN clause to the query.
-func (b *DeleteBuilder) LeftJoin(join string) *DeleteBuilder {
-	return b.JoinClause("LEFT JOIN " + join)
+//
+// DELETE ... JOIN is an MySQL specific extension
+func (b *DeleteBuilder) LeftJoin(join string, rest ...interface{}) *DeleteBuilder {
+	return b.JoinClause("LEFT JOIN "+join, rest...)
 }
 
 // RightJoin adds a RIGHT JOIN clause to the query.
-func (b *DeleteBuilder) RightJoin(join string) *DeleteBuilder {
-	return b.JoinClause("RIGHT JOIN " + join)
+//
+// DELETE ... JOIN is an MySQL specific extension
+func (b *DeleteBuilder) RightJoin(join string, rest ...interface{}) *DeleteBuilder {
+	return b.JoinClause("RIGHT JOIN "+join, rest...)
 }
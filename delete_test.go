@@ -113,6 +113,28 @@ func TestDeleteUsing(t *testing.T) {
 	assert.Equal(t, []interface{}{42}, args)
 }
 
+func TestDeleteUsingSelect(t *testing.T) {
+	flagged := Select("account_id").
+		From("flags").
+		Where(Eq{"kind": "fraud"})
+
+	b := Delete("sessions").
+		UsingSelect(flagged, "f").
+		Where("sessions.account_id = f.account_id").
+		Where(Lt{"sessions.created_at": "2020-01-01"}).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql :=
+		"DELETE FROM sessions " +
+			"USING (SELECT account_id FROM flags WHERE kind = $1) AS f " +
+			"WHERE sessions.account_id = f.account_id AND sessions.created_at < $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"fraud", "2020-01-01"}, args)
+}
+
 func TestDeleteBuilderReturning(t *testing.T) {
 	b := Delete("a").
 		Where("id = ?", 42).
@@ -228,3 +250,61 @@ func TestIssue11(t *testing.T) {
 	expectedArgs := []interface{}{1}
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestDeleteBuilderReturningExpr(t *testing.T) {
+	b := Delete("a").
+		Where("id = ?", 42).
+		Returning("id").
+		ReturningExpr(Expr("COALESCE(note, ?) AS note", "none"))
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a WHERE id = ? RETURNING id, COALESCE(note, ?) AS note", sql)
+	assert.Equal(t, []interface{}{42, "none"}, args)
+}
+
+func TestDeleteBuilderJoinArgs(t *testing.T) {
+	b := Delete("oi").
+		From("order_items oi").
+		Join("orders o ON o.id = oi.order_id AND o.status = ?", "cancelled").
+		LeftJoin("refunds r ON r.order_id = o.id").
+		Where("o.created_at < ?", "2020-01-01").
+		Where("r.id IS NULL")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "DELETE oi FROM order_items oi " +
+		"JOIN orders o ON o.id = oi.order_id AND o.status = ? " +
+		"LEFT JOIN refunds r ON r.order_id = o.id " +
+		"WHERE o.created_at < ? AND r.id IS NULL"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"cancelled", "2020-01-01"}, args)
+}
+
+func TestDeleteBuilderBoundedPurge(t *testing.T) {
+	b := Delete("events").
+		Where(Lt{"created_at": "2020-01-01"}).
+		OrderBy("created_at").
+		Limit(1000)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM events WHERE created_at < ? ORDER BY created_at LIMIT 1000", sql)
+	assert.Equal(t, []interface{}{"2020-01-01"}, args)
+}
+
+func TestDeleteBuilderClone(t *testing.T) {
+	base := Delete("users").Where("id = ?", 1)
+	clone := base.Clone().Where("active = ?", false).Returning("id")
+
+	sql, args, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, args, err = clone.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users WHERE id = ? AND active = ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{1, false}, args)
+}
@@ -0,0 +1,60 @@
+package sqrl
+
+import "fmt"
+
+// Dialect groups settings specific to a database, so they can be set together.
+type Dialect struct {
+	// Placeholder is the placeholder format of the queries, Question if nil.
+	Placeholder PlaceholderFormat
+	// Quote is the quoting style of identifiers, see Ident.
+	Quote Quote
+	// FetchSyntax enables the SQL standard OFFSET ... FETCH syntax instead
+	// of LIMIT and OFFSET, see SelectBuilder.FetchSyntax.
+	FetchSyntax bool
+	// CastShorthand enables PostgreSQL :: cast operator, see StatementBuilderType.Cast.
+	CastShorthand bool
+	// PostgresClauses enables PostgreSQL-only clauses RETURNING, ON CONFLICT
+	// and DISTINCT ON. Queries using them fail to build with dialects that do
+	// not enable them, builders without a dialect always render them.
+	PostgresClauses bool
+}
+
+var (
+	// Postgres uses Dollar placeholders, double quoted identifiers, :: casts
+	// and PostgreSQL-only clauses.
+	Postgres = Dialect{Placeholder: Dollar, Quote: QuoteDouble, CastShorthand: true, PostgresClauses: true}
+
+	// MySQL uses Question placeholders and backtick quoted identifiers.
+	MySQL = Dialect{Placeholder: Question, Quote: QuoteBacktick}
+
+	// SQLServer uses AtP placeholders, square bracket quoted identifiers
+	// and OFFSET ... FETCH syntax.
+	SQLServer = Dialect{Placeholder: AtP, Quote: QuoteBracket, FetchSyntax: true}
+)
+
+// Dialect sets the placeholder format, identifier quoting and other settings
+// of d for any child builders.
+//
+// Ex:
+//     pg := StatementBuilder.Dialect(Postgres)
+//     pg.Select().Column(pg.Ident("select")).From("t").Where("id = ?", 1)
+//     // SELECT "select" FROM t WHERE id = $1
+func (b StatementBuilderType) Dialect(d Dialect) StatementBuilderType {
+	b.fetchSyntax = d.FetchSyntax
+	b.castShorthand = d.CastShorthand
+	b.noPostgresClauses = !d.PostgresClauses
+	placeholder := d.Placeholder
+	if placeholder == nil {
+		placeholder = Question
+	}
+	return b.PlaceholderFormat(placeholder).QuoteWith(d.Quote)
+}
+
+// checkPostgresClause returns an error if PostgreSQL-only clause is not
+// enabled by the dialect of the builder.
+func (b StatementBuilderType) checkPostgresClause(clause string) error {
+	if b.noPostgresClauses {
+		return fmt.Errorf("%s clause is not supported by the dialect", clause)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementBuilderDialect(t *testing.T) {
+	cases := []struct {
+		dialect     Dialect
+		expectedSql string
+	}{
+		{Postgres, `SELECT "select" FROM t WHERE id = $1`},
+		{MySQL, "SELECT `select` FROM t WHERE id = ?"},
+		{SQLServer, "SELECT [select] FROM t WHERE id = @p1"},
+	}
+
+	for _, c := range cases {
+		sb := StatementBuilder.Dialect(c.dialect)
+
+		sql, args, err := sb.Select().Column(sb.Ident("select")).From("t").Where("id = ?", 1).ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, c.expectedSql, sql)
+		assert.Equal(t, []interface{}{1}, args)
+	}
+}
+
+func TestStatementBuilderDialectInherited(t *testing.T) {
+	sb := StatementBuilder.Dialect(Postgres)
+
+	sql, _, err := sb.Update("t").Set("a", 1).Where("id = ?", 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = $1 WHERE id = $2", sql)
+
+	sql, _, err = sb.Delete("t").Where("id = ?", 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE id = $1", sql)
+}
+
+func TestStatementBuilderDialectCustom(t *testing.T) {
+	sb := StatementBuilder.Dialect(Dialect{Quote: QuoteDouble})
+
+	sql, args, err := sb.Select().Column(sb.Ident("select")).From("t").Where("a = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "select" FROM t WHERE a = ?`, sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestStatementBuilderDialectFetchSyntax(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(SQLServer).Select("a").From("b").OrderBy("a").Limit(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY", sql)
+	assert.Equal(t, []interface{}{uint64(0), uint64(10)}, args)
+
+	sql, _, err = StatementBuilder.Dialect(Postgres).Select("a").From("b").OrderBy("a").Limit(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a LIMIT 10", sql)
+}
+
+func TestStatementBuilderDialectPostgresClauses(t *testing.T) {
+	pg := StatementBuilder.Dialect(Postgres)
+
+	sql, _, err := pg.Insert("t").Columns("a").Values(1).OnConflict("a").DoNothing().Returning("id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t (a) VALUES ($1) ON CONFLICT (a) DO NOTHING RETURNING id", sql)
+
+	sql, _, err = pg.Select("a", "b").DistinctOn("a").From("t").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT DISTINCT ON (a) a, b FROM t", sql)
+
+	for _, d := range []Dialect{MySQL, SQLServer} {
+		sb := StatementBuilder.Dialect(d)
+
+		_, _, err = sb.Insert("t").Columns("a").Values(1).OnConflict("a").DoNothing().ToSql()
+		assert.EqualError(t, err, "ON CONFLICT clause is not supported by the dialect")
+
+		_, _, err = sb.Insert("t").Columns("a").Values(1).Returning("id").ToSql()
+		assert.EqualError(t, err, "RETURNING clause is not supported by the dialect")
+
+		_, _, err = sb.Update("t").Set("a", 1).Returning("id").ToSql()
+		assert.EqualError(t, err, "RETURNING clause is not supported by the dialect")
+
+		_, _, err = sb.Delete("t").Returning("id").ToSql()
+		assert.EqualError(t, err, "RETURNING clause is not supported by the dialect")
+
+		_, _, err = sb.Select("a", "b").DistinctOn("a").From("t").ToSql()
+		assert.EqualError(t, err, "DISTINCT ON clause is not supported by the dialect")
+	}
+
+	// builders without a dialect render the clauses unconditionally
+	_, _, err = Delete("t").Returning("id").ToSql()
+	assert.NoError(t, err)
+}
+
+func TestStatementBuilderDialectFetchSyntaxErrors(t *testing.T) {
+	sb := StatementBuilder.Dialect(SQLServer)
+
+	_, _, err := sb.Select("a").From("b").Limit(10).ToSql()
+	assert.EqualError(t, err, "select statements must have ORDER BY clause to use OFFSET ... FETCH syntax")
+
+	_, _, err = sb.Select("a").From("b").Offset(10).ToSql()
+	assert.EqualError(t, err, "select statements must have ORDER BY clause to use OFFSET ... FETCH syntax")
+
+	_, _, err = sb.Update("t").Set("a", 1).Limit(10).ToSql()
+	assert.EqualError(t, err, "update statements cannot have LIMIT or OFFSET with OFFSET ... FETCH syntax")
+
+	_, _, err = sb.Delete("t").Offset(10).ToSql()
+	assert.EqualError(t, err, "delete statements cannot have LIMIT or OFFSET with OFFSET ... FETCH syntax")
+
+	sql, _, err := sb.Select("a").From("b").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b", sql)
+}
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"fmt"
-	"io"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -35,12 +35,12 @@ func (e expr) ToSql() (string, []interface{}, error) {
 		}
 		switch arg := e.args[i-1].(type) {
 		case Sqlizer:
-			sql, vs, err := arg.ToSql()
+			sql, vs, err := nestedToSql(arg)
 			if err != nil {
 				return err
 			}
 			args = append(args, vs...)
-			fmt.Fprintf(buf, sql)
+			buf.WriteString(sql)
 		default:
 			args = append(args, arg)
 			buf.WriteRune('?')
@@ -53,23 +53,162 @@ func (e expr) ToSql() (string, []interface{}, error) {
 	return sql, args, nil
 }
 
-type exprs []expr
+type rawExpr expr
 
-func (es exprs) AppendToSql(w io.Writer, sep string, args []interface{}) ([]interface{}, error) {
-	for i, e := range es {
-		if i > 0 {
-			_, err := io.WriteString(w, sep)
+// Raw builds a Sqlizer returning sql and args exactly as given. Unlike Expr,
+// Sqlizer args are not expanded, so it can be used to run fixed (e.g. already
+// prepared or cached) statements through ExecWith and QueryWith.
+// Ex:
+//     QueryWith(NewStmtCacher(db), Raw("SELECT name FROM users WHERE id = ?", 1))
+func Raw(sql string, args ...interface{}) rawExpr {
+	return rawExpr{sql: sql, args: args}
+}
+
+func (e rawExpr) ToSql() (string, []interface{}, error) {
+	return e.sql, e.args, nil
+}
+
+// explainExpr prepends EXPLAIN to a complete statement
+type explainExpr struct {
+	stmt    Sqlizer
+	options []string
+}
+
+// Explain wraps statement s with EXPLAIN, keeping its args and placeholders,
+// so that the query plan can be read with QueryWith. Options are rendered in
+// parentheses as supported by PostgreSQL, without options plain EXPLAIN
+// understood by MySQL is used.
+// Ex:
+//     Explain(Select("*").From("users").Where("id = ?", 1), "ANALYZE", "FORMAT JSON")
+//     == "EXPLAIN (ANALYZE, FORMAT JSON) SELECT * FROM users WHERE id = ?"
+func Explain(s Sqlizer, options ...string) explainExpr {
+	return explainExpr{stmt: s, options: options}
+}
+
+func (e explainExpr) ToSql() (sql string, args []interface{}, err error) {
+	if e.stmt == nil {
+		err = fmt.Errorf("EXPLAIN requires a statement")
+		return
+	}
+
+	sql, args, err = e.stmt.ToSql()
+	if err != nil {
+		return
+	}
+
+	if len(e.options) > 0 {
+		sql = fmt.Sprintf("EXPLAIN (%s) %s", strings.Join(e.options, ", "), sql)
+	} else {
+		sql = "EXPLAIN " + sql
+	}
+	return
+}
+
+type inExpr expr
+
+// In builds value expression expanding slice args into lists of placeholders.
+//
+// Ex:
+//     .Where(In("status = ? AND id IN (?)", "active", []int{1, 2, 3}))
+//     // status = ? AND id IN (?,?,?)
+func In(sql string, args ...interface{}) inExpr {
+	return inExpr{sql: sql, args: args}
+}
+
+func (e inExpr) ToSql() (string, []interface{}, error) {
+	args := make([]interface{}, 0, len(e.args))
+	count := 0
+	sql, err := replacePlaceholders(e.sql, func(buf *bytes.Buffer, i int) error {
+		count = i
+		if i > len(e.args) {
+			return fmt.Errorf("not enough args for placeholder %d, got %d", i, len(e.args))
+		}
+
+		switch arg := e.args[i-1].(type) {
+		case Sqlizer:
+			sql, vs, err := nestedToSql(arg)
 			if err != nil {
-				return nil, err
+				return err
+			}
+			args = append(args, vs...)
+			buf.WriteString(sql)
+		default:
+			if !isListType(arg) {
+				args = append(args, arg)
+				buf.WriteRune('?')
+				return nil
+			}
+
+			argVal := reflect.ValueOf(arg)
+			if argVal.Len() == 0 {
+				return fmt.Errorf("empty slice passed for placeholder %d", i)
 			}
+			for j := 0; j < argVal.Len(); j++ {
+				args = append(args, argVal.Index(j).Interface())
+			}
+			buf.WriteString(Placeholders(argVal.Len()))
 		}
-		_, err := io.WriteString(w, e.sql)
-		if err != nil {
-			return nil, err
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if count != len(e.args) {
+		return "", nil, fmt.Errorf("got %d args for %d placeholders", len(e.args), count)
+	}
+	return sql, args, nil
+}
+
+// inChunkedExpr compares column against a long list of values split into chunks
+type inChunkedExpr struct {
+	column    string
+	values    interface{}
+	chunkSize int
+}
+
+// InChunked is syntactic sugar for use with Where/Having methods comparing
+// column against a large slice of values. Values are split into IN lists of at
+// most chunkSize placeholders joined with OR, so that a single list does not
+// exceed parameter limits of the database driver.
+// Ex:
+//     .Where(InChunked("id", []int{1, 2, 3, 4, 5}, 2))
+//     == "(id IN (?,?) OR id IN (?,?) OR id IN (?))"
+func InChunked(column string, values interface{}, chunkSize int) inChunkedExpr {
+	return inChunkedExpr{column: column, values: values, chunkSize: chunkSize}
+}
+
+func (e inChunkedExpr) ToSql() (sql string, args []interface{}, err error) {
+	if e.chunkSize <= 0 {
+		err = fmt.Errorf("chunk size must be positive, got %d", e.chunkSize)
+		return
+	}
+	if !isListType(e.values) {
+		err = fmt.Errorf("expected slice or array of values, not %T", e.values)
+		return
+	}
+
+	valVal := reflect.ValueOf(e.values)
+	if valVal.Len() == 0 {
+		return "(1=0)", []interface{}{}, nil // Portable FALSE
+	}
+
+	var chunks []string
+	for start := 0; start < valVal.Len(); start += e.chunkSize {
+		end := start + e.chunkSize
+		if end > valVal.Len() {
+			end = valVal.Len()
 		}
-		args = append(args, e.args...)
+		for i := start; i < end; i++ {
+			args = append(args, valVal.Index(i).Interface())
+		}
+		chunks = append(chunks, fmt.Sprintf("%s IN (%s)", e.column, Placeholders(end-start)))
+	}
+
+	sql = strings.Join(chunks, " OR ")
+	if len(chunks) > 1 {
+		sql = "(" + sql + ")"
 	}
-	return args, nil
+	return
 }
 
 // aliasExpr helps to alias part of SQL query generated with underlying "expr"
@@ -87,16 +226,98 @@ func Alias(expr Sqlizer, alias string) aliasExpr {
 }
 
 func (e aliasExpr) ToSql() (sql string, args []interface{}, err error) {
-	sql, args, err = e.expr.ToSql()
+	sql, args, err = nestedToSql(e.expr)
 	if err == nil {
 		sql = fmt.Sprintf("(%s) AS %s", sql, e.alias)
 	}
 	return
 }
 
+// asExpr aliases a plain SQL string or a Sqlizer
+type asExpr struct {
+	expr  interface{}
+	alias string
+}
+
+// As defines alias for column in SelectBuilder. Unlike Alias, expr can be
+// a plain SQL string, which is not wrapped in parentheses. Sqlizers (e.g.
+// scalar subqueries) are wrapped in parentheses and their args are kept.
+// Ex:
+//     .Column(As("COUNT(*)", "total")) == "COUNT(*) AS total"
+//     .Column(As(Select("COUNT(*)").From("posts"), "cnt")) == "(SELECT COUNT(*) FROM posts) AS cnt"
+func As(expr interface{}, alias string) asExpr {
+	return asExpr{expr, alias}
+}
+
+func (e asExpr) ToSql() (sql string, args []interface{}, err error) {
+	switch expr := e.expr.(type) {
+	case string:
+		sql = fmt.Sprintf("%s AS %s", expr, e.alias)
+	case Sqlizer:
+		sql, args, err = Alias(expr, e.alias).ToSql()
+	default:
+		err = fmt.Errorf("expected string or Sqlizer, not %T", expr)
+	}
+	return
+}
+
+// subqueryExpr renders subquery in parentheses after an operator
+type subqueryExpr struct {
+	operator string
+	sub      Sqlizer
+}
+
+// Exists is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(Exists(Select("1").From("orders o").Where("o.user_id = users.id")))
+//     == "EXISTS (SELECT 1 FROM orders o WHERE o.user_id = users.id)"
+func Exists(sub Sqlizer) subqueryExpr {
+	return subqueryExpr{operator: "EXISTS", sub: sub}
+}
+
+// NotExists is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(NotExists(Select("1").From("orders o").Where("o.user_id = users.id")))
+//     == "NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = users.id)"
+func NotExists(sub Sqlizer) subqueryExpr {
+	return subqueryExpr{operator: "NOT EXISTS", sub: sub}
+}
+
+// InSelect is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(InSelect("id", Select("user_id").From("admins")))
+//     == "id IN (SELECT user_id FROM admins)"
+func InSelect(column string, sub Sqlizer) subqueryExpr {
+	return subqueryExpr{operator: column + " IN", sub: sub}
+}
+
+// NotInSelect is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(NotInSelect("id", Select("user_id").From("bans")))
+//     == "id NOT IN (SELECT user_id FROM bans)"
+func NotInSelect(column string, sub Sqlizer) subqueryExpr {
+	return subqueryExpr{operator: column + " NOT IN", sub: sub}
+}
+
+func (e subqueryExpr) ToSql() (sql string, args []interface{}, err error) {
+	if e.sub == nil {
+		err = fmt.Errorf("%s requires a subquery", e.operator)
+		return
+	}
+
+	sql, args, err = nestedToSql(e.sub)
+	if err == nil {
+		sql = fmt.Sprintf("%s (%s)", e.operator, sql)
+	}
+	return
+}
+
 // Eq is syntactic sugar for use with Where/Having/Set methods.
+// Sqlizer values (e.g. scalar subqueries) are rendered in parentheses.
 // Ex:
 //     .Where(Eq{"id": 1})
+//     .Where(Eq{"status": Select("status").From("defaults").Limit(1)})
+//     == "status = (SELECT status FROM defaults LIMIT 1)"
 type Eq map[string]interface{}
 
 func (eq Eq) toSql(useNotOpr bool) (sql string, args []interface{}, err error) {
@@ -115,10 +336,19 @@ func (eq Eq) toSql(useNotOpr bool) (sql string, args []interface{}, err error) {
 		inEmptyExpr = "(1=1)" // Portable TRUE
 	}
 
-	for key, val := range eq {
+	for _, key := range sortedKeys(eq) {
 		expr := ""
+		val := eq[key]
 
 		switch v := val.(type) {
+		case Sqlizer:
+			var subArgs []interface{}
+			if expr, subArgs, err = subqueryValue(key, equalOpr, v); err != nil {
+				return
+			}
+			exprs = append(exprs, expr)
+			args = append(args, subArgs...)
+			continue
 		case driver.Valuer:
 			if val, err = v.Value(); err != nil {
 				return
@@ -136,10 +366,30 @@ func (eq Eq) toSql(useNotOpr bool) (sql string, args []interface{}, err error) {
 						args = []interface{}{}
 					}
 				} else {
+					// NULL never matches IN list, so nil items are compared separately
+					hasNull, count := false, 0
 					for i := 0; i < valVal.Len(); i++ {
-						args = append(args, valVal.Index(i).Interface())
+						item := valVal.Index(i)
+						if isNilValue(item) {
+							hasNull = true
+							continue
+						}
+						args = append(args, item.Interface())
+						count++
+					}
+
+					nullExpr := fmt.Sprintf("%s %s NULL", key, nullOpr)
+					inExpr := fmt.Sprintf("%s %s (%s)", key, inOpr, Placeholders(count))
+					switch {
+					case !hasNull:
+						expr = inExpr
+					case count == 0:
+						expr = nullExpr
+					case useNotOpr:
+						expr = fmt.Sprintf("(%s AND %s)", inExpr, nullExpr)
+					default:
+						expr = fmt.Sprintf("(%s OR %s)", inExpr, nullExpr)
 					}
-					expr = fmt.Sprintf("%s %s (%s)", key, inOpr, Placeholders(valVal.Len()))
 				}
 			} else {
 				expr = fmt.Sprintf("%s %s ?", key, equalOpr)
@@ -167,7 +417,43 @@ func (neq NotEq) ToSql() (sql string, args []interface{}, err error) {
 	return Eq(neq).toSql(true)
 }
 
+// EqOptional is syntactic sugar for use with Where/Having methods.
+// Unlike Eq, nil values and nil pointers are skipped instead of producing
+// "IS NULL", non-nil pointers are dereferenced. Keys are sorted.
+// Ex:
+//     var status *string
+//     .Where(EqOptional{"status": status, "id": 1}) == "id = ?"
+type EqOptional map[string]interface{}
+
+// ToSql builds the query into a SQL string and bound args.
+func (eq EqOptional) ToSql() (sql string, args []interface{}, err error) {
+	keys := sortedKeys(eq)
+
+	exprs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		val := reflect.ValueOf(eq[key])
+		if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+			continue
+		}
+		if val.Kind() == reflect.Ptr {
+			val = val.Elem()
+		}
+
+		var keySql string
+		var keyArgs []interface{}
+		keySql, keyArgs, err = Eq{key: val.Interface()}.ToSql()
+		if err != nil {
+			return
+		}
+		exprs = append(exprs, keySql)
+		args = append(args, keyArgs...)
+	}
+	sql = strings.Join(exprs, " AND ")
+	return
+}
+
 // Lt is syntactic sugar for use with Where/Having/Set methods.
+// Sqlizer values (e.g. scalar subqueries) are rendered in parentheses.
 // Ex:
 //     .Where(Lt{"id": 1})
 type Lt map[string]interface{}
@@ -186,10 +472,19 @@ func (lt Lt) toSql(opposite, orEq bool) (sql string, args []interface{}, err err
 		opr = fmt.Sprintf("%s%s", opr, "=")
 	}
 
-	for key, val := range lt {
+	for _, key := range sortedKeys(lt) {
 		expr := ""
+		val := lt[key]
 
 		switch v := val.(type) {
+		case Sqlizer:
+			var subArgs []interface{}
+			if expr, subArgs, err = subqueryValue(key, opr, v); err != nil {
+				return
+			}
+			exprs = append(exprs, expr)
+			args = append(args, subArgs...)
+			continue
 		case driver.Valuer:
 			if val, err = v.Value(); err != nil {
 				return
@@ -245,6 +540,235 @@ func (gtOrEq GtOrEq) ToSql() (sql string, args []interface{}, err error) {
 	return Lt(gtOrEq).toSql(true, true)
 }
 
+// Like is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(Like{"name": "%bob%"}) == "name LIKE '%bob%'"
+type Like map[string]interface{}
+
+func (lk Like) toSql(opr string) (sql string, args []interface{}, err error) {
+	keys := sortedKeys(lk)
+
+	exprs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		val := lk[key]
+
+		switch v := val.(type) {
+		case driver.Valuer:
+			if val, err = v.Value(); err != nil {
+				return
+			}
+		}
+
+		if val == nil {
+			err = fmt.Errorf("cannot use null with %s operator on %s", opr, key)
+			return
+		}
+		if isListType(val) {
+			err = fmt.Errorf("cannot use array or slice with %s operator on %s", opr, key)
+			return
+		}
+
+		exprs = append(exprs, fmt.Sprintf("%s %s ?", key, opr))
+		args = append(args, val)
+	}
+	sql = strings.Join(exprs, " AND ")
+	return
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (lk Like) ToSql() (sql string, args []interface{}, err error) {
+	return lk.toSql("LIKE")
+}
+
+// NotLike is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(NotLike{"name": "%bob%"}) == "name NOT LIKE '%bob%'"
+type NotLike Like
+
+// ToSql builds the query into a SQL string and bound args.
+func (nlk NotLike) ToSql() (sql string, args []interface{}, err error) {
+	return Like(nlk).toSql("NOT LIKE")
+}
+
+// ILike is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(ILike{"name": "%bob%"}) == "name ILIKE '%bob%'"
+//
+// ILIKE is PostgreSQL specific extension
+type ILike Like
+
+// ToSql builds the query into a SQL string and bound args.
+func (ilk ILike) ToSql() (sql string, args []interface{}, err error) {
+	return Like(ilk).toSql("ILIKE")
+}
+
+// NotILike is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(NotILike{"name": "%bob%"}) == "name NOT ILIKE '%bob%'"
+//
+// ILIKE is PostgreSQL specific extension
+type NotILike Like
+
+// ToSql builds the query into a SQL string and bound args.
+func (nilk NotILike) ToSql() (sql string, args []interface{}, err error) {
+	return Like(nilk).toSql("NOT ILIKE")
+}
+
+// Between is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(Between{Column: "age", From: 18, To: 65}) == "age BETWEEN 18 AND 65"
+type Between struct {
+	Column string
+	From   interface{}
+	To     interface{}
+}
+
+func (b Between) toSql(opr string) (sql string, args []interface{}, err error) {
+	from, err := betweenBound(b.From)
+	if err != nil {
+		return
+	}
+	to, err := betweenBound(b.To)
+	if err != nil {
+		return
+	}
+
+	if from == nil || to == nil {
+		err = fmt.Errorf("cannot use null with %s operator on %s", opr, b.Column)
+		return
+	}
+	if isListType(from) || isListType(to) {
+		err = fmt.Errorf("cannot use array or slice with %s operator on %s", opr, b.Column)
+		return
+	}
+
+	sql = fmt.Sprintf("%s %s ? AND ?", b.Column, opr)
+	args = []interface{}{from, to}
+	return
+}
+
+// betweenBound unwraps driver.Valuer bound like Eq does, nil pointers are
+// reported as nil
+func betweenBound(val interface{}) (interface{}, error) {
+	if val == nil || isNilValue(reflect.ValueOf(val)) {
+		return nil, nil
+	}
+	if v, ok := val.(driver.Valuer); ok {
+		return v.Value()
+	}
+	return val, nil
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b Between) ToSql() (sql string, args []interface{}, err error) {
+	return b.toSql("BETWEEN")
+}
+
+// NotBetween is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(NotBetween{Column: "age", From: 18, To: 65}) == "age NOT BETWEEN 18 AND 65"
+type NotBetween Between
+
+// ToSql builds the query into a SQL string and bound args.
+func (nb NotBetween) ToSql() (sql string, args []interface{}, err error) {
+	return Between(nb).toSql("NOT BETWEEN")
+}
+
+// cmpExpr compares two columns or expressions without binding args
+type cmpExpr struct {
+	left  string
+	op    string
+	right string
+}
+
+// Cmp is syntactic sugar for use with Where/Having methods comparing
+// column to column. Both sides are used as is, nothing is bound as an arg.
+// Supported operators are =, <>, !=, <, <=, > and >=.
+// Ex:
+//     .Where(Cmp("start_date", "<", "end_date")) == "start_date < end_date"
+func Cmp(left, op, right string) cmpExpr {
+	return cmpExpr{left: left, op: op, right: right}
+}
+
+func (e cmpExpr) ToSql() (sql string, args []interface{}, err error) {
+	switch e.op {
+	case "=", "<>", "!=", "<", "<=", ">", ">=":
+		sql = fmt.Sprintf("%s %s %s", e.left, e.op, e.right)
+	default:
+		err = fmt.Errorf("unsupported comparison operator %q", e.op)
+	}
+	return
+}
+
+// tupleExpr compares a row value of columns against a row value of args
+type tupleExpr struct {
+	columns  []string
+	operator string
+	values   []interface{}
+}
+
+// EqTuple is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(EqTuple([]string{"a", "b"}, []interface{}{1, 2})) == "(a, b) = (?, ?)"
+func EqTuple(columns []string, values []interface{}) tupleExpr {
+	return tupleExpr{columns: columns, operator: "=", values: values}
+}
+
+// LtTuple is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(LtTuple([]string{"a", "b"}, []interface{}{1, 2})) == "(a, b) < (?, ?)"
+func LtTuple(columns []string, values []interface{}) tupleExpr {
+	return tupleExpr{columns: columns, operator: "<", values: values}
+}
+
+// LtOrEqTuple is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(LtOrEqTuple([]string{"a", "b"}, []interface{}{1, 2})) == "(a, b) <= (?, ?)"
+func LtOrEqTuple(columns []string, values []interface{}) tupleExpr {
+	return tupleExpr{columns: columns, operator: "<=", values: values}
+}
+
+// GtTuple is syntactic sugar for use with Where/Having methods.
+// Useful for keyset pagination.
+// Ex:
+//     .Where(GtTuple([]string{"created_at", "id"}, []interface{}{t, lastID}))
+//     == "(created_at, id) > (?, ?)"
+func GtTuple(columns []string, values []interface{}) tupleExpr {
+	return tupleExpr{columns: columns, operator: ">", values: values}
+}
+
+// GtOrEqTuple is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(GtOrEqTuple([]string{"a", "b"}, []interface{}{1, 2})) == "(a, b) >= (?, ?)"
+func GtOrEqTuple(columns []string, values []interface{}) tupleExpr {
+	return tupleExpr{columns: columns, operator: ">=", values: values}
+}
+
+func (e tupleExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.columns) == 0 {
+		err = fmt.Errorf("tuple comparison requires at least one column")
+		return
+	}
+	if len(e.columns) != len(e.values) {
+		err = fmt.Errorf("tuple comparison got %d values for %d columns", len(e.values), len(e.columns))
+		return
+	}
+
+	placeholders := make([]string, len(e.values))
+	for i, val := range e.values {
+		if isListType(val) {
+			err = fmt.Errorf("cannot use array or slice in tuple comparison on %s", e.columns[i])
+			return
+		}
+		placeholders[i] = "?"
+	}
+
+	sql = fmt.Sprintf("(%s) %s (%s)",
+		strings.Join(e.columns, ", "), e.operator, strings.Join(placeholders, ", "))
+	args = e.values
+	return
+}
+
 type conj []Sqlizer
 
 func (c conj) join(sep string) (sql string, args []interface{}, err error) {
@@ -265,7 +789,9 @@ func (c conj) join(sep string) (sql string, args []interface{}, err error) {
 	return
 }
 
-// And is syntactic sugar that glues where/having parts with AND clause
+// And is syntactic sugar that glues where/having parts with AND clause.
+// Rendered parts are wrapped in parentheses, so And and Or can be nested
+// without changing precedence. Empty parts are skipped.
 // Ex:
 //     .Where(And{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
 type And conj
@@ -275,7 +801,8 @@ func (a And) ToSql() (string, []interface{}, error) {
 	return conj(a).join(" AND ")
 }
 
-// Or is syntactic sugar that glues where/having parts with OR clause
+// Or is syntactic sugar that glues where/having parts with OR clause.
+// Like And, rendered parts are wrapped in parentheses.
 // Ex:
 //     .Where(Or{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
 type Or conj
@@ -293,6 +820,36 @@ func isListType(val interface{}) bool {
 	return valVal.Kind() == reflect.Array || valVal.Kind() == reflect.Slice
 }
 
+// subqueryValue compares key to a value built by Sqlizer (e.g. scalar subquery),
+// which is rendered in parentheses instead of being bound as an arg
+func subqueryValue(key, opr string, value Sqlizer) (string, []interface{}, error) {
+	sql, args, err := nestedToSql(value)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%s %s (%s)", key, opr, sql), args, nil
+}
+
+// sortedKeys returns keys of m in sorted order, so that map expressions
+// always produce the same SQL
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isNilValue reports whether v holds nil interface or nil pointer
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
 func hasSqlizer(args []interface{}) bool {
 	for _, arg := range args {
 		_, ok := arg.(Sqlizer)
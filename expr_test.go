@@ -139,6 +139,127 @@ func TestGtOrEqToSql(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestLikeToSql(t *testing.T) {
+	b := Like{"name": "%bob%", "email": "%@example.com"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "email LIKE ? AND name LIKE ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"%@example.com", "%bob%"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestNotLikeToSql(t *testing.T) {
+	b := NotLike{"name": "%bob%"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "name NOT LIKE ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"%bob%"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestILikeToSql(t *testing.T) {
+	b := ILike{"name": "%bob%", "city": "ber%"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "city ILIKE ? AND name ILIKE ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"ber%", "%bob%"}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, _, err = NotILike{"name": "%bob%"}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "name NOT ILIKE ?", sql)
+}
+
+func TestLikeSliceToSql(t *testing.T) {
+	_, _, err := Like{"name": []string{"a", "b"}}.ToSql()
+	assert.EqualError(t, err, "cannot use array or slice with LIKE operator on name")
+
+	_, _, err = NotILike{"name": nil}.ToSql()
+	assert.EqualError(t, err, "cannot use null with NOT ILIKE operator on name")
+}
+
+func TestBetweenToSql(t *testing.T) {
+	b := Between{Column: "age", From: 18, To: 65}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "age BETWEEN ? AND ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{18, 65}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestNotBetweenToSql(t *testing.T) {
+	b := Or{NotBetween{Column: "age", From: 18, To: 65}, Eq{"vip": true}}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(age NOT BETWEEN ? AND ? OR vip = ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{18, 65, true}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestBetweenNilToSql(t *testing.T) {
+	_, _, err := Between{Column: "age", From: 18}.ToSql()
+	assert.EqualError(t, err, "cannot use null with BETWEEN operator on age")
+
+	_, _, err = NotBetween{Column: "age", To: 65}.ToSql()
+	assert.EqualError(t, err, "cannot use null with NOT BETWEEN operator on age")
+
+	var to *int
+	_, _, err = Between{Column: "age", From: 18, To: to}.ToSql()
+	assert.EqualError(t, err, "cannot use null with BETWEEN operator on age")
+
+	_, _, err = Between{Column: "age", From: sql.NullInt64{}, To: 65}.ToSql()
+	assert.EqualError(t, err, "cannot use null with BETWEEN operator on age")
+}
+
+func TestBetweenValuerToSql(t *testing.T) {
+	b := Between{Column: "age", From: sql.NullInt64{Int64: 18, Valid: true}, To: 65}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "age BETWEEN ? AND ?", sql)
+	assert.Equal(t, []interface{}{int64(18), 65}, args)
+}
+
+func TestConjNestingToSql(t *testing.T) {
+	b := And{
+		Eq{"active": true},
+		Or{
+			Eq{"a": 1},
+			And{Gt{"b": 2}, Lt{"c": 3}},
+			Eq{"d": 4, "e": 5},
+		},
+		Expr("f IS NOT NULL"),
+	}
+	sql, args, err := Select("*").From("t").Where(b).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM t WHERE (active = $1 AND " +
+		"(a = $2 OR (b > $3 AND c < $4) OR d = $5 AND e = $6) AND " +
+		"f IS NOT NULL)"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{true, 1, 2, 3, 4, 5}, args)
+
+	sql, args, err = Or{And{}, Eq{"a": 1}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(a = ?)", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
 func TestExprNilToSql(t *testing.T) {
 	var b Sqlizer
 	b = NotEq{"name": nil}
@@ -212,3 +333,312 @@ func TestExprSqlizer(t *testing.T) {
 		assert.Equal(t, []interface{}{42, 42}, args)
 	}
 }
+
+func TestInToSql(t *testing.T) {
+	b := In("status = ? AND id IN (?)", "active", []int{1, 2, 3})
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "status = ? AND id IN (?,?,?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"active", 1, 2, 3}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, args, err = In("data = ?", []byte("raw")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data = ?", sql)
+	assert.Equal(t, []interface{}{[]byte("raw")}, args)
+}
+
+func TestInToSqlErr(t *testing.T) {
+	_, _, err := In("id IN (?) AND x = ?", []int{1}).ToSql()
+	assert.EqualError(t, err, "not enough args for placeholder 2, got 1")
+
+	_, _, err = In("id IN (?)", []int{1}, 2).ToSql()
+	assert.EqualError(t, err, "got 2 args for 1 placeholders")
+
+	_, _, err = In("id IN (?)", []int{}).ToSql()
+	assert.EqualError(t, err, "empty slice passed for placeholder 1")
+}
+
+func TestInChunkedToSql(t *testing.T) {
+	sql, args, err := InChunked("id", []int{1, 2, 3, 4, 5}, 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(id IN (?,?) OR id IN (?,?) OR id IN (?))", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, args)
+
+	sql, args, err = InChunked("id", []int{1, 2}, 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "id IN (?,?)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Select("*").From("users").
+		Where(InChunked("id", []int{}, 2)).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE (1=0)", sql)
+	assert.Empty(t, args)
+}
+
+func TestInChunkedToSqlErr(t *testing.T) {
+	_, _, err := InChunked("id", []int{1}, 0).ToSql()
+	assert.EqualError(t, err, "chunk size must be positive, got 0")
+
+	_, _, err = InChunked("id", 1, 2).ToSql()
+	assert.EqualError(t, err, "expected slice or array of values, not int")
+}
+
+func TestExistsToSql(t *testing.T) {
+	sub := Select("1").From("orders o").Where("o.user_id = u.id AND o.total > ?", 100)
+	b := Select("u.id").
+		From("users u").
+		Where("u.active = ?", true).
+		Where(Or{Exists(sub), NotExists(Select("1").From("bans b").Where("b.user_id = u.id"))}).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT u.id FROM users u WHERE u.active = $1 AND " +
+		"(EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id AND o.total > $2) OR " +
+		"NOT EXISTS (SELECT 1 FROM bans b WHERE b.user_id = u.id))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{true, 100}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = Exists(nil).ToSql()
+	assert.EqualError(t, err, "EXISTS requires a subquery")
+}
+
+func TestInSelectToSql(t *testing.T) {
+	admins := Select("user_id").From("admins").Where("level > ?", 2)
+	bans := Select("user_id").From("bans").Where(Eq{"permanent": []bool{true}})
+	b := Select("id").
+		From("users").
+		Where(Eq{"status": "active"}).
+		Where(And{InSelect("id", admins), NotInSelect("id", bans)}).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id FROM users WHERE status = $1 AND " +
+		"(id IN (SELECT user_id FROM admins WHERE level > $2) AND " +
+		"id NOT IN (SELECT user_id FROM bans WHERE permanent IN ($3)))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"active", 2, true}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestEqOptionalToSql(t *testing.T) {
+	var nilStatus *string
+	zero := 0
+	name := "foo"
+
+	b := EqOptional{"status": nilStatus, "age": &zero, "name": &name, "id": 1, "deleted": nil}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "age = ? AND id = ? AND name = ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{0, 1, "foo"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestEqOptionalEmpty(t *testing.T) {
+	var status *string
+
+	sql, args, err := Select("*").
+		From("users").
+		Where(EqOptional{"status": status}).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = Select("*").
+		From("users").
+		Where(EqOptional{"status": status}).
+		Where("active = ?", true).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE active = ?", sql)
+	assert.Equal(t, []interface{}{true}, args)
+}
+
+func TestTupleToSql(t *testing.T) {
+	created := "2020-01-01"
+
+	sql, args, err := GtTuple([]string{"created_at", "id"}, []interface{}{created, 10}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at, id) > (?, ?)", sql)
+	assert.Equal(t, []interface{}{created, 10}, args)
+
+	for expected, b := range map[string]tupleExpr{
+		"(a, b) = (?, ?)":  EqTuple([]string{"a", "b"}, []interface{}{1, 2}),
+		"(a, b) < (?, ?)":  LtTuple([]string{"a", "b"}, []interface{}{1, 2}),
+		"(a, b) <= (?, ?)": LtOrEqTuple([]string{"a", "b"}, []interface{}{1, 2}),
+		"(a, b) >= (?, ?)": GtOrEqTuple([]string{"a", "b"}, []interface{}{1, 2}),
+	} {
+		sql, args, err := b.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sql)
+		assert.Equal(t, []interface{}{1, 2}, args)
+	}
+}
+
+func TestTupleDollar(t *testing.T) {
+	sql, args, err := Select("*").
+		From("posts").
+		Where("author_id = ?", 5).
+		Where(GtTuple([]string{"created_at", "id"}, []interface{}{"2020-01-01", 10})).
+		OrderBy("created_at", "id").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM posts WHERE author_id = $1 AND (created_at, id) > ($2, $3) ORDER BY created_at, id"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{5, "2020-01-01", 10}, args)
+}
+
+func TestTupleErrors(t *testing.T) {
+	_, _, err := GtTuple(nil, nil).ToSql()
+	assert.EqualError(t, err, "tuple comparison requires at least one column")
+
+	_, _, err = GtTuple([]string{"a", "b"}, []interface{}{1}).ToSql()
+	assert.EqualError(t, err, "tuple comparison got 1 values for 2 columns")
+
+	_, _, err = EqTuple([]string{"a"}, []interface{}{[]int{1}}).ToSql()
+	assert.EqualError(t, err, "cannot use array or slice in tuple comparison on a")
+}
+
+func TestAsToSql(t *testing.T) {
+	sql, args, err := As("COUNT(*)", "total").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COUNT(*) AS total", sql)
+	assert.Empty(t, args)
+
+	_, _, err = As(42, "answer").ToSql()
+	assert.EqualError(t, err, "expected string or Sqlizer, not int")
+}
+
+func TestAsSelect(t *testing.T) {
+	cnt := Select("COUNT(*)").From("posts p").Where("p.user_id = u.id AND p.status = ?", "published")
+
+	sql, args, err := Select("u.id").
+		Column(As(cnt, "cnt")).
+		Column(As("u.name", "name")).
+		From("users u").
+		Where("u.active = ?", true).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT u.id, (SELECT COUNT(*) FROM posts p WHERE p.user_id = u.id AND p.status = $1) AS cnt, " +
+		"u.name AS name FROM users u WHERE u.active = $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"published", true}, args)
+}
+
+func TestEqNilToSql(t *testing.T) {
+	sql, args, err := Eq{"deleted_at": nil}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "deleted_at IS NULL", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = NotEq{"deleted_at": nil}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "deleted_at IS NOT NULL", sql)
+	assert.Empty(t, args)
+}
+
+func TestEqNilInSliceToSql(t *testing.T) {
+	sql, args, err := Eq{"x": []interface{}{1, nil, 2}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(x IN (?,?) OR x IS NULL)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = NotEq{"x": []interface{}{1, nil}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(x NOT IN (?) AND x IS NOT NULL)", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, args, err = Eq{"x": []interface{}{nil}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "x IS NULL", sql)
+	assert.Empty(t, args)
+
+	var nilPtr *int
+	sql, args, err = Eq{"x": []*int{nilPtr}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "x IS NULL", sql)
+	assert.Empty(t, args)
+}
+
+func TestCmpToSql(t *testing.T) {
+	for _, op := range []string{"=", "<>", "!=", "<", "<=", ">", ">="} {
+		sql, args, err := Cmp("start_date", op, "end_date").ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, "start_date "+op+" end_date", sql)
+		assert.Empty(t, args)
+	}
+
+	_, _, err := Cmp("a", "LIKE", "b").ToSql()
+	assert.EqualError(t, err, `unsupported comparison operator "LIKE"`)
+}
+
+func TestEqSqlizerValueToSql(t *testing.T) {
+	sub := Select("status").From("defaults").Where("kind = ?", "user").Limit(1)
+
+	sql, args, err := Select("*").
+		From("users").
+		Where(Eq{"status": sub}).
+		Where(NotEq{"id": Expr("?", 0)}).
+		Where(Gt{"age": Select("AVG(age)").From("users")}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM users " +
+		"WHERE status = (SELECT status FROM defaults WHERE kind = $1 LIMIT 1) " +
+		"AND id <> ($2) " +
+		"AND age > (SELECT AVG(age) FROM users)"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"user", 0}, args)
+
+	_, _, err = Eq{"status": Select()}.ToSql()
+	assert.EqualError(t, err, "select statements must have at least one result column")
+}
+
+func TestRawToSql(t *testing.T) {
+	sub := Select("1")
+	sql, args, err := Raw("SELECT $1, ?", 1, sub).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT $1, ?", sql)
+	assert.Equal(t, []interface{}{1, sub}, args)
+}
+
+func TestExplainToSql(t *testing.T) {
+	q := Select("*").From("users").Where("id = ?", 1).PlaceholderFormat(Dollar)
+
+	sql, args, err := Explain(q, "ANALYZE", "FORMAT JSON").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "EXPLAIN (ANALYZE, FORMAT JSON) SELECT * FROM users WHERE id = $1", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, args, err = Explain(Update("users").Set("name", "foo")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "EXPLAIN UPDATE users SET name = ?", sql)
+	assert.Equal(t, []interface{}{"foo"}, args)
+
+	_, _, err = Explain(nil).ToSql()
+	assert.EqualError(t, err, "EXPLAIN requires a statement")
+
+	_, _, err = Explain(Select()).ToSql()
+	assert.EqualError(t, err, "select statements must have at least one result column")
+}
@@ -0,0 +1,233 @@
+package sqrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// litExpr is a literal value bound as an arg
+type litExpr struct {
+	value interface{}
+}
+
+// Lit wraps value to be bound as an arg in helpers like Coalesce or Concat,
+// which otherwise use strings verbatim as identifiers or expressions.
+//
+// Ex:
+//     Coalesce("nickname", "name", Lit("anonymous")) // COALESCE(nickname, name, ?)
+func Lit(value interface{}) litExpr {
+	return litExpr{value: value}
+}
+
+func (e litExpr) ToSql() (sql string, args []interface{}, err error) {
+	return "?", []interface{}{e.value}, nil
+}
+
+// funcExpr renders operands joined with a separator, wrapped in a function
+// call if name is set
+type funcExpr struct {
+	name     string
+	sep      string
+	operands []interface{}
+}
+
+// Coalesce returns COALESCE expression of given operands.
+// Strings are used verbatim, Sqlizers are rendered with their args merged
+// and other values are bound as args, see Lit.
+//
+// Ex:
+//     Select().Column(As(Coalesce("nickname", "name", Lit("")), "display_name"))
+//     // SELECT (COALESCE(nickname, name, ?)) AS display_name
+func Coalesce(operands ...interface{}) funcExpr {
+	return funcExpr{name: "COALESCE", sep: ", ", operands: operands}
+}
+
+// Concat returns string concatenation of given operands using the SQL
+// standard || operator. Operands are treated the same way as in Coalesce.
+//
+// Ex:
+//     Concat("first_name", Lit(" "), "last_name") // first_name || ? || last_name
+func Concat(operands ...interface{}) funcExpr {
+	return funcExpr{sep: " || ", operands: operands}
+}
+
+func (e funcExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.operands) == 0 {
+		err = fmt.Errorf("expected at least one operand")
+		return
+	}
+
+	parts := make([]string, len(e.operands))
+	for i, operand := range e.operands {
+		var operandArgs []interface{}
+		parts[i], operandArgs, err = operandToSql(operand)
+		if err != nil {
+			return
+		}
+		args = append(args, operandArgs...)
+	}
+
+	sql = strings.Join(parts, e.sep)
+	if len(e.name) > 0 {
+		sql = fmt.Sprintf("%s(%s)", e.name, sql)
+	}
+	return
+}
+
+// castExpr converts operand to another type
+type castExpr struct {
+	operand   interface{}
+	typ       string
+	shorthand bool
+}
+
+// Cast returns the SQL standard CAST(... AS typ) expression. The operand is
+// treated the same way as in Coalesce.
+//
+// See StatementBuilderType.Cast for PostgreSQL :: shorthand.
+//
+// Ex:
+//     Cast("price", "INT") // CAST(price AS INT)
+func Cast(operand interface{}, typ string) castExpr {
+	return StatementBuilder.Cast(operand, typ)
+}
+
+func (e castExpr) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = operandToSql(e.operand)
+	if err != nil {
+		return
+	}
+
+	if !e.shorthand {
+		sql = fmt.Sprintf("CAST(%s AS %s)", sql, e.typ)
+		return
+	}
+	if !isSimpleOperand(sql) {
+		sql = "(" + sql + ")"
+	}
+	sql = sql + "::" + e.typ
+	return
+}
+
+// isSimpleOperand reports whether sql is an identifier or a placeholder,
+// which can be used with operators without parentheses
+func isSimpleOperand(sql string) bool {
+	if sql == "?" {
+		return true
+	}
+	for _, r := range sql {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_', r == '.', r == '"':
+		default:
+			return false
+		}
+	}
+	return len(sql) > 0
+}
+
+// operandToSql renders operand of a function or an operator. Strings are
+// used verbatim, nested builders are put in parentheses as subqueries and
+// values other than Sqlizers are bound as args.
+func operandToSql(operand interface{}) (string, []interface{}, error) {
+	switch op := operand.(type) {
+	case string:
+		return op, nil, nil
+	case rawSqlizer:
+		sql, args, err := op.toSqlRaw()
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + sql + ")", args, nil
+	case Sqlizer:
+		return op.ToSql()
+	default:
+		return "?", []interface{}{op}, nil
+	}
+}
+
+// valuesExpr is a VALUES row constructor used as a table
+type valuesExpr struct {
+	rows    [][]interface{}
+	alias   string
+	columns []string
+}
+
+// Values returns VALUES list of rows usable as a table, e.g. with
+// SelectBuilder.FromExpr or in IN comparisons. Values are bound as args
+// in row-major order, Sqlizers are rendered in place.
+// If alias is empty, the list is not aliased.
+//
+// Ex:
+//     Values([][]interface{}{{1, "a"}, {2, "b"}}, "t", "id", "name")
+//     // (VALUES (?,?),(?,?)) AS t(id,name)
+func Values(rows [][]interface{}, alias string, columns ...string) valuesExpr {
+	return valuesExpr{rows: rows, alias: alias, columns: columns}
+}
+
+func (e valuesExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.rows) == 0 {
+		err = fmt.Errorf("values list must have at least one row")
+		return
+	}
+
+	rowStrings := make([]string, len(e.rows))
+	for r, row := range e.rows {
+		if len(row) != len(e.rows[0]) {
+			err = fmt.Errorf("values row %d has %d values, expected %d", r, len(row), len(e.rows[0]))
+			return
+		}
+		if len(e.columns) > 0 && len(row) != len(e.columns) {
+			err = fmt.Errorf("values row %d has %d values for %d columns", r, len(row), len(e.columns))
+			return
+		}
+
+		valueStrings := make([]string, len(row))
+		for v, val := range row {
+			if s, ok := val.(Sqlizer); ok {
+				var valArgs []interface{}
+				valueStrings[v], valArgs, err = nestedToSql(s)
+				if err != nil {
+					return
+				}
+				args = append(args, valArgs...)
+			} else {
+				valueStrings[v] = "?"
+				args = append(args, val)
+			}
+		}
+		rowStrings[r] = "(" + strings.Join(valueStrings, ",") + ")"
+	}
+
+	sql = "(VALUES " + strings.Join(rowStrings, ",") + ")"
+	if len(e.alias) > 0 {
+		sql += " AS " + e.alias
+		if len(e.columns) > 0 {
+			sql += "(" + strings.Join(e.columns, ",") + ")"
+		}
+	}
+	return
+}
+
+// collateExpr applies collation to operand
+type collateExpr struct {
+	operand   interface{}
+	collation string
+}
+
+// Collate returns operand with COLLATE clause for locale aware comparisons.
+// The operand is treated the same way as in Coalesce, collation is used verbatim.
+//
+// Ex:
+//     Expr("? < ?", Collate("name", `"de_DE"`), "M") // name COLLATE "de_DE" < ?
+func Collate(operand interface{}, collation string) collateExpr {
+	return collateExpr{operand: operand, collation: collation}
+}
+
+func (e collateExpr) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = operandToSql(e.operand)
+	if err == nil {
+		sql = fmt.Sprintf("%s COLLATE %s", sql, e.collation)
+	}
+	return
+}
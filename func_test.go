@@ -0,0 +1,123 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesce(t *testing.T) {
+	sql, args, err := Coalesce("nickname", "name", Lit("")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COALESCE(nickname, name, ?)", sql)
+	assert.Equal(t, []interface{}{""}, args)
+
+	sql, args, err = Select("id").
+		Column(As(Coalesce(Select("MAX(total)").From("orders").Where("user_id = ?", 1), 0), "max_total")).
+		From("users").
+		Where(Eq{"id": 1}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, (COALESCE((SELECT MAX(total) FROM orders WHERE user_id = $1), $2)) AS max_total FROM users WHERE id = $3", sql)
+	assert.Equal(t, []interface{}{1, 0, 1}, args)
+}
+
+func TestConcat(t *testing.T) {
+	sql, args, err := Select().
+		Column(Concat("first_name", Lit(" "), "last_name")).
+		From("users").
+		Where(Expr("? = ?", Concat("LOWER(first_name)", Lit(" "), "LOWER(last_name)"), "foo bar")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT first_name || ? || last_name FROM users WHERE LOWER(first_name) || ? || LOWER(last_name) = ?", sql)
+	assert.Equal(t, []interface{}{" ", " ", "foo bar"}, args)
+
+	_, _, err = Concat().ToSql()
+	assert.EqualError(t, err, "expected at least one operand")
+}
+
+func TestCast(t *testing.T) {
+	sql, args, err := Cast("price", "INT").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CAST(price AS INT)", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = Cast(Expr("a + ?", 1), "NUMERIC(10, 2)").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CAST(a + ? AS NUMERIC(10, 2))", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestCastPostgres(t *testing.T) {
+	pg := StatementBuilder.Dialect(Postgres)
+
+	sql, args, err := pg.Select().
+		Column(pg.Cast("u.price", "int")).
+		Column(pg.Cast(Expr("a + ?", 1), "numeric")).
+		Column(pg.Cast(Lit("2020-01-01"), "date")).
+		From("users u").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.price::int, (a + $1)::numeric, $2::date FROM users u", sql)
+	assert.Equal(t, []interface{}{1, "2020-01-01"}, args)
+}
+
+func TestValues(t *testing.T) {
+	sql, args, err := Values([][]interface{}{{1, "a"}, {2, "b"}}, "t", "id", "name").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(VALUES (?,?),(?,?)) AS t(id,name)", sql)
+	assert.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	sql, args, err = Select("u.*").
+		From("users u").
+		JoinClause(Expr("JOIN ? ON u.id = t.id", Values([][]interface{}{{1, Expr("LOWER(?)", "A")}, {2, "b"}}, "t", "id", "name"))).
+		Where("u.active = ?", true).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.* FROM users u JOIN (VALUES ($1,LOWER($2)),($3,$4)) AS t(id,name) ON u.id = t.id WHERE u.active = $5", sql)
+	assert.Equal(t, []interface{}{1, "A", 2, "b", true}, args)
+}
+
+func TestValuesFromExpr(t *testing.T) {
+	sql, args, err := Insert("users").
+		Columns("id", "name").
+		Select(Select("*").FromExpr(Values([][]interface{}{{1, "a"}, {2, "b"}}, "t", "id", "name"))).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) SELECT * FROM (VALUES ($1,$2),($3,$4)) AS t(id,name)", sql)
+	assert.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	sql, _, err = Select("*").From("users").Where(Expr("id IN ?", Values([][]interface{}{{1}, {2}}, ""))).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (VALUES (?),(?))", sql)
+}
+
+func TestValuesErrors(t *testing.T) {
+	_, _, err := Values(nil, "t").ToSql()
+	assert.EqualError(t, err, "values list must have at least one row")
+
+	_, _, err = Values([][]interface{}{{1, 2}, {3}}, "t").ToSql()
+	assert.EqualError(t, err, "values row 1 has 1 values, expected 2")
+
+	_, _, err = Values([][]interface{}{{1, 2}}, "t", "id").ToSql()
+	assert.EqualError(t, err, "values row 0 has 2 values for 1 columns")
+}
+
+func TestCollate(t *testing.T) {
+	sql, args, err := Select("*").
+		From("users").
+		Where(Expr("? = ?", Collate("name", "utf8mb4_bin"), "Foo")).
+		Where(Expr("name < ?", Collate(Lit("M"), `"C"`))).
+		OrderByCollate("name", `"de_DE"`, "ASC").
+		OrderByCollate("id", `"C"`, "").
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := `SELECT * FROM users WHERE name COLLATE utf8mb4_bin = ? AND name < ? COLLATE "C" ` +
+		`ORDER BY name COLLATE "de_DE" ASC, id COLLATE "C"`
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"Foo", "M"}, args)
+}
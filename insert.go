@@ -7,22 +7,45 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 )
 
+// Default is a value rendered as DEFAULT keyword without any args, which sets
+// a column to its default value in InsertBuilder.Values and UpdateBuilder.Set.
+//
+// Ex:
+//     Insert("users").Columns("id", "name").Values(Default, "foo")
+//     // INSERT INTO users (id,name) VALUES (DEFAULT,?)
+var Default Sqlizer = defaultKeyword{}
+
+type defaultKeyword struct{}
+
+func (defaultKeyword) ToSql() (string, []interface{}, error) {
+	return "DEFAULT", nil, nil
+}
+
 // InsertBuilder builds SQL INSERT statements.
 type InsertBuilder struct {
 	StatementBuilderType
 
 	returning
 
-	prefixes exprs
-	options  []string
-	into     string
-	columns  []string
-	values   [][]interface{}
-	suffixes exprs
-	iselect  *SelectBuilder
+	prefixes   []Sqlizer
+	options    []string
+	into       string
+	columns    []string
+	values     [][]interface{}
+	suffixes   []Sqlizer
+	suffixArgs []interface{}
+	iselect    *SelectBuilder
+
+	defaultValues bool
+
+	onConflict          *OnConflictBuilder
+	duplicateKeyUpdates []setClause
+
+	err error
 }
 
 // NewInsertBuilder creates new instance of InsertBuilder
@@ -30,15 +53,46 @@ func NewInsertBuilder(b StatementBuilderType) *InsertBuilder {
 	return &InsertBuilder{StatementBuilderType: b}
 }
 
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+//
+// Clauses are copied, but values and nested builders (e.g. Select)
+// are shared between the original and the copy.
+func (b *InsertBuilder) Clone() *InsertBuilder {
+	c := *b
+	c.returning = append(returning(nil), b.returning...)
+	c.prefixes = append([]Sqlizer(nil), b.prefixes...)
+	c.options = append([]string(nil), b.options...)
+	c.columns = append([]string(nil), b.columns...)
+	c.values = append([][]interface{}(nil), b.values...)
+	c.suffixes = append([]Sqlizer(nil), b.suffixes...)
+	c.suffixArgs = append([]interface{}(nil), b.suffixArgs...)
+	c.duplicateKeyUpdates = append([]setClause(nil), b.duplicateKeyUpdates...)
+	if b.onConflict != nil {
+		onConflict := *b.onConflict
+		onConflict.insert = &c
+		onConflict.setClauses = append([]setClause(nil), b.onConflict.setClauses...)
+		c.onConflict = &onConflict
+	}
+	return &c
+}
+
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b *InsertBuilder) RunWith(runner BaseRunner) *InsertBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
 
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *InsertBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *InsertBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b *InsertBuilder) Exec() (sql.Result, error) {
-	return b.ExecContext(context.Background())
+	return b.ExecContext(b.runContext())
 }
 
 // ExecContext builds and Execs the query with the Runner set by RunWith using given context.
@@ -51,7 +105,7 @@ func (b *InsertBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *InsertBuilder) Query() (RowsScanner, error) {
-	return b.QueryContext(context.Background())
+	return b.QueryContext(b.runContext())
 }
 
 // QueryContext builds and runs the query using given context and Query command.
@@ -64,7 +118,7 @@ func (b *InsertBuilder) QueryContext(ctx context.Context) (RowsScanner, error) {
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b *InsertBuilder) QueryRow() RowScanner {
-	return b.QueryRowContext(context.Background())
+	return b.QueryRowContext(b.runContext())
 }
 
 // QueryRowContext builds and runs the query using given context.
@@ -93,19 +147,53 @@ func (b *InsertBuilder) PlaceholderFormat(f PlaceholderFormat) *InsertBuilder {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *InsertBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
+	if b.err != nil {
+		err = b.err
+		return
+	}
 	if len(b.into) == 0 {
 		err = fmt.Errorf("insert statements must specify a table")
 		return
 	}
-	if len(b.values) == 0 && b.iselect == nil {
+	if b.defaultValues && (len(b.values) > 0 || len(b.columns) > 0 || b.iselect != nil) {
+		err = fmt.Errorf("insert statements cannot have default values with columns, values or select clause")
+		return
+	}
+	if len(b.values) == 0 && b.iselect == nil && !b.defaultValues {
 		err = fmt.Errorf("insert statements must have at least one set of values or select clause")
 		return
 	}
+	if len(b.values) > 0 && b.iselect != nil {
+		err = fmt.Errorf("insert statements cannot have both values and select clause")
+		return
+	}
+	if len(b.columns) > 0 {
+		for r, row := range b.values {
+			if len(row) != len(b.columns) {
+				err = fmt.Errorf("insert values row %d has %d values for %d columns", r, len(row), len(b.columns))
+				return
+			}
+		}
+	}
 
 	sql := &bytes.Buffer{}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.prefixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
@@ -126,7 +214,9 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(") ")
 	}
 
-	if b.iselect != nil {
+	if b.defaultValues {
+		sql.WriteString("DEFAULT VALUES")
+	} else if b.iselect != nil {
 		args, err = b.appendSelectToSQL(sql, args)
 	} else {
 		args, err = b.appendValuesToSQL(sql, args)
@@ -135,7 +225,28 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		return
 	}
 
+	if b.onConflict != nil {
+		if err = b.checkPostgresClause("ON CONFLICT"); err != nil {
+			return
+		}
+		args, err = b.onConflict.appendToSql(sql, args)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(b.duplicateKeyUpdates) > 0 {
+		sql.WriteString(" ON DUPLICATE KEY UPDATE ")
+		args, err = appendSetClausesToSql(b.duplicateKeyUpdates, sql, args)
+		if err != nil {
+			return
+		}
+	}
+
 	if len(b.returning) > 0 {
+		if err = b.checkPostgresClause("RETURNING"); err != nil {
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -144,10 +255,14 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.suffixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
+	args = append(args, b.suffixArgs...)
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	sqlStr = sql.String()
 	return
 }
 
@@ -197,7 +312,7 @@ func (b *InsertBuilder) appendSelectToSQL(w io.Writer, args []interface{}) ([]in
 		return args, errors.New("select clause for insert statements are not set")
 	}
 
-	selectClause, sArgs, err := b.iselect.ToSql()
+	selectClause, sArgs, err := nestedToSql(b.iselect)
 	if err != nil {
 		return args, err
 	}
@@ -214,6 +329,13 @@ func (b *InsertBuilder) Prefix(sql string, args ...interface{}) *InsertBuilder {
 	return b
 }
 
+// PrefixExpr adds an expression built by expr to the beginning of the query.
+// Its args are placed before args of the query.
+func (b *InsertBuilder) PrefixExpr(expr Sqlizer) *InsertBuilder {
+	b.prefixes = append(b.prefixes, newPart(expr))
+	return b
+}
+
 // Options adds keyword options before the INTO clause of the query.
 func (b *InsertBuilder) Options(options ...string) *InsertBuilder {
 	b.options = append(b.options, options...)
@@ -238,6 +360,25 @@ func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
 	return b
 }
 
+// Rows adds values of each struct in a slice as a row to the query.
+//
+// Columns are read from `db` struct tags, falling back to field names,
+// fields tagged with `db:"-"` are skipped. If columns were set before
+// with Columns, values are taken in that order.
+// Ex:
+//     Insert("users").Rows([]User{{Name: "foo"}, {Name: "bar"}})
+func (b *InsertBuilder) Rows(structs interface{}) *InsertBuilder {
+	columns, rows, err := structRows(structs, b.columns)
+	if err != nil {
+		b.err = fmt.Errorf("insert rows: %v", err)
+		return b
+	}
+
+	b.columns = columns
+	b.values = append(b.values, rows...)
+	return b
+}
+
 // Returning adds columns to RETURNING clause of the query
 //
 // INSERT ... RETURNING is PostgreSQL specific extension
@@ -254,22 +395,49 @@ func (b *InsertBuilder) ReturningSelect(from *SelectBuilder, alias string) *Inse
 	return b
 }
 
+// ReturningExpr adds an expression with bound args to RETURNING clause of the query
+//
+// INSERT ... RETURNING is PostgreSQL specific extension
+func (b *InsertBuilder) ReturningExpr(expr interface{}, args ...interface{}) *InsertBuilder {
+	b.returning.ReturningExpr(expr, args...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *InsertBuilder) Suffix(sql string, args ...interface{}) *InsertBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
 	return b
 }
 
+// SuffixExpr adds an expression built by expr to the end of the query.
+// Its args are placed after args of the query.
+func (b *InsertBuilder) SuffixExpr(expr Sqlizer) *InsertBuilder {
+	b.suffixes = append(b.suffixes, newPart(expr))
+	return b
+}
+
+// SuffixArgs appends args to the end of the query args without adding any SQL.
+// It is a low-level escape hatch for placeholders of a suffix composed in
+// pieces, args are placed after args of all other clauses including suffixes.
+func (b *InsertBuilder) SuffixArgs(args ...interface{}) *InsertBuilder {
+	b.suffixArgs = append(b.suffixArgs, args...)
+	return b
+}
+
 // SetMap set columns and values for insert builder from a map of column name and value
-// note that it will reset all previous columns and values was set if any
+// note that it will reset all previous columns and values was set if any.
+// Columns are sorted, so the same map always produces the same query.
 func (b *InsertBuilder) SetMap(clauses map[string]interface{}) *InsertBuilder {
 	// TODO: replace resetting previous values with extending existing ones?
 	cols := make([]string, 0, len(clauses))
-	vals := make([]interface{}, 0, len(clauses))
-
-	for col, val := range clauses {
+	for col := range clauses {
 		cols = append(cols, col)
-		vals = append(vals, val)
+	}
+	sort.Strings(cols)
+
+	vals := make([]interface{}, len(cols))
+	for i, col := range cols {
+		vals[i] = clauses[col]
 	}
 
 	b.columns = cols
@@ -277,8 +445,16 @@ func (b *InsertBuilder) SetMap(clauses map[string]interface{}) *InsertBuilder {
 	return b
 }
 
+// DefaultValues makes the query insert a single row with default values of
+// all columns, e.g. "INSERT INTO events DEFAULT VALUES". It cannot be used
+// together with Columns, Values or Select.
+func (b *InsertBuilder) DefaultValues() *InsertBuilder {
+	b.defaultValues = true
+	return b
+}
+
 // Select set Select clause for insert query
-// If Values and Select are used, then Select has higher priority
+// Select replaces VALUES clause, so Values and Select cannot be used together
 func (b *InsertBuilder) Select(sb *SelectBuilder) *InsertBuilder {
 	b.iselect = sb
 	return b
@@ -38,6 +38,18 @@ func TestInsertBuilderToSqlErr(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestInsertBuilderColumnCountMismatch(t *testing.T) {
+	_, _, err := Insert("users").
+		Columns("id", "name", "email").
+		Values(1, "foo", "foo@example.com").
+		Values(2, "bar").
+		ToSql()
+	assert.EqualError(t, err, "insert values row 1 has 2 values for 3 columns")
+
+	_, _, err = Insert("users").Values(1, "foo").Values(2, "bar").ToSql()
+	assert.NoError(t, err)
+}
+
 func TestInsertBuilderPlaceholders(t *testing.T) {
 	b := Insert("test").Values(1, 2)
 
@@ -131,6 +143,22 @@ func TestInsertBuilderSetMap(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestInsertBuilderSetMapSorted(t *testing.T) {
+	clauses := map[string]interface{}{"c": 3, "a": 1, "d": 4, "b": 2}
+
+	sql, args, err := Insert("table").SetMap(clauses).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO table (a,b,c,d) VALUES (?,?,?,?)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	for i := 0; i < 10; i++ {
+		otherSql, otherArgs, err := Insert("table").SetMap(clauses).ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, sql, otherSql)
+		assert.Equal(t, args, otherArgs)
+	}
+}
+
 func TestInsertBuilderSelect(t *testing.T) {
 	sb := Select("field1").From("table1").Where(Eq{"field1": 1})
 	ib := Insert("table2").Columns("field1").Select(sb)
@@ -144,3 +172,138 @@ func TestInsertBuilderSelect(t *testing.T) {
 	expectedArgs := []interface{}{1}
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestInsertBuilderSelectPlaceholders(t *testing.T) {
+	sb := StatementBuilder.PlaceholderFormat(Dollar)
+	active := sb.Select("id", "email").From("users").Where(Eq{"active": true}).Where("created_at > ?", 10)
+	ib := sb.Insert("subscribers").Columns("user_id", "email").Select(active).Suffix("RETURNING ?", 1)
+
+	sql, args, err := ib.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "INSERT INTO subscribers (user_id,email) " +
+		"SELECT id, email FROM users WHERE active = $1 AND created_at > $2 RETURNING $3"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{true, 10, 1}, args)
+}
+
+func TestInsertBuilderValuesAndSelectErr(t *testing.T) {
+	_, _, err := Insert("a").Columns("b").Values(1).Select(Select("b").From("c")).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderDefaultValues(t *testing.T) {
+	sql, args, err := Insert("events").DefaultValues().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO events DEFAULT VALUES", sql)
+	assert.Empty(t, args)
+
+	sql, _, err = Insert("events").DefaultValues().Returning("id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO events DEFAULT VALUES RETURNING id", sql)
+
+	expectedErr := "insert statements cannot have default values with columns, values or select clause"
+	_, _, err = Insert("events").Values(1).DefaultValues().ToSql()
+	assert.EqualError(t, err, expectedErr)
+	_, _, err = Insert("events").Columns("id").DefaultValues().ToSql()
+	assert.EqualError(t, err, expectedErr)
+	_, _, err = Insert("events").Select(Select("1")).DefaultValues().ToSql()
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestInsertBuilderDefault(t *testing.T) {
+	sql, args, err := Insert("users").
+		Columns("id", "name", "created_at").
+		Values(Default, "foo", Default).
+		Values(Default, "bar", "2020-01-01").
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name,created_at) VALUES (DEFAULT,$1,DEFAULT),(DEFAULT,$2,$3)", sql)
+	assert.Equal(t, []interface{}{"foo", "bar", "2020-01-01"}, args)
+
+	sql, args, err = Update("users").Set("name", "foo").Set("updated_at", Default).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, updated_at = DEFAULT", sql)
+	assert.Equal(t, []interface{}{"foo"}, args)
+}
+
+func TestInsertBuilderReturningExpr(t *testing.T) {
+	db := &DBStub{}
+	b := Insert("orders").
+		Columns("net").
+		Values(100).
+		Returning("id").
+		ReturningExpr("net * ? AS gross", 1.2).
+		RunWith(db)
+
+	var id int
+	var gross float64
+	err := b.Scan(&id, &gross)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO orders (net) VALUES (?) RETURNING id, net * ? AS gross", db.LastQueryRowSql)
+	assert.Equal(t, []interface{}{100, 1.2}, db.LastQueryRowArgs)
+}
+
+type insertRow struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	Password string `db:"-"`
+	Age      int
+}
+
+func TestInsertBuilderRows(t *testing.T) {
+	rows := []insertRow{
+		{ID: 1, Name: "foo", Password: "x", Age: 10},
+		{ID: 2, Name: "bar", Password: "y", Age: 20},
+		{ID: 3, Name: "baz", Password: "z", Age: 30},
+	}
+
+	sql, args, err := Insert("users").Rows(rows).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name,Age) VALUES (?,?,?),(?,?,?),(?,?,?)", sql)
+	assert.Equal(t, []interface{}{1, "foo", 10, 2, "bar", 20, 3, "baz", 30}, args)
+}
+
+func TestInsertBuilderRowsColumnOrder(t *testing.T) {
+	rows := []*insertRow{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}
+
+	sql, args, err := Insert("users").Columns("name", "id").Rows(rows).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,id) VALUES (?,?),(?,?)", sql)
+	assert.Equal(t, []interface{}{"foo", 1, "bar", 2}, args)
+}
+
+func TestInsertBuilderRowsErrors(t *testing.T) {
+	_, _, err := Insert("users").Columns("id", "Password").Rows([]insertRow{{ID: 1}}).ToSql()
+	assert.EqualError(t, err, `insert rows: row 0: struct sqrl.insertRow has no field for column "Password"`)
+
+	type other struct {
+		ID int `db:"id"`
+	}
+	mixed := []interface{}{insertRow{ID: 1}, other{ID: 2}}
+	_, _, err = Insert("users").Rows(mixed).ToSql()
+	assert.EqualError(t, err, `insert rows: row 1: struct sqrl.other has no field for column "name"`)
+
+	_, _, err = Insert("users").Rows(insertRow{}).ToSql()
+	assert.EqualError(t, err, "insert rows: expected slice of structs, got sqrl.insertRow")
+
+	_, _, err = Insert("users").Rows([]insertRow{}).ToSql()
+	assert.EqualError(t, err, "insert rows: expected non-empty slice of structs")
+}
+
+func TestInsertBuilderClone(t *testing.T) {
+	base := Insert("users").Columns("id", "name").Values(1, "foo").OnConflict("id").DoNothing()
+	clone := base.Clone().Values(2, "bar").OnConflict("name").DoUpdate(map[string]interface{}{"name": Excluded("name")})
+
+	sql, args, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (?,?) ON CONFLICT (id) DO NOTHING", sql)
+	assert.Equal(t, []interface{}{1, "foo"}, args)
+
+	sql, args, err = clone.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (?,?),(?,?) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name", sql)
+	assert.Equal(t, []interface{}{1, "foo", 2, "bar"}, args)
+}
@@ -0,0 +1,218 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// LoadStrict sets whether LoadAll and LoadOne fail on columns that have no
+// matching struct field. Loading is strict by default, otherwise values of
+// such columns are discarded.
+func (b *SelectBuilder) LoadStrict(strict bool) *SelectBuilder {
+	b.loadLenient = !strict
+	return b
+}
+
+// LoadAll runs the query with the Runner set by RunWith and scans all rows into
+// dest, which must be a pointer to a slice of structs or pointers to structs.
+//
+// Columns are matched to struct fields by `db` tags, falling back to field names.
+// Embedded structs are flattened, see InsertBuilder.Rows.
+func (b *SelectBuilder) LoadAll(dest interface{}) error {
+	return b.LoadAllContext(b.runContext(), dest)
+}
+
+// LoadAllContext runs the query using given context and scans all rows into dest.
+//
+// See LoadAll.
+func (b *SelectBuilder) LoadAllContext(ctx context.Context, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected pointer to slice, got %T", dest)
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to slice of structs, got %T", dest)
+	}
+
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	targets, err := newScanTargets(rows, structType, !b.loadLenient)
+	if err != nil {
+		return err
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	for rows.Next() {
+		elem := reflect.New(structType)
+		if err := rows.Scan(targets.addrs(elem.Elem())...); err != nil {
+			return err
+		}
+		if elemType.Kind() == reflect.Ptr {
+			result = reflect.Append(result, elem)
+		} else {
+			result = reflect.Append(result, elem.Elem())
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	slice.Set(result)
+	return nil
+}
+
+// LoadOne runs the query with the Runner set by RunWith and scans the first row
+// into dest, which must be a pointer to a struct. If the query returns no rows,
+// sql.ErrNoRows is returned.
+//
+// See LoadAll.
+func (b *SelectBuilder) LoadOne(dest interface{}) error {
+	return b.LoadOneContext(b.runContext(), dest)
+}
+
+// LoadOneContext runs the query using given context and scans the first row into dest.
+//
+// See LoadOne.
+func (b *SelectBuilder) LoadOneContext(ctx context.Context, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to struct, got %T", dest)
+	}
+
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	targets, err := newScanTargets(rows, v.Elem().Type(), !b.loadLenient)
+	if err != nil {
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(targets.addrs(v.Elem())...); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// LoadMaps runs the query with the Runner set by RunWith and returns all rows
+// as maps keyed by column names. It is useful when columns are not known in
+// advance, e.g. for export tools.
+//
+// NULL values are returned as nil, []byte values are copied so that they stay
+// valid after the rows are closed.
+func (b *SelectBuilder) LoadMaps() ([]map[string]interface{}, error) {
+	return b.LoadMapsContext(b.runContext())
+}
+
+// LoadMapsContext runs the query using given context and returns all rows as maps.
+//
+// See LoadMaps.
+func (b *SelectBuilder) LoadMapsContext(ctx context.Context) ([]map[string]interface{}, error) {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	addrs := make([]interface{}, len(columns))
+	for i := range values {
+		addrs[i] = &values[i]
+	}
+
+	var result []map[string]interface{}
+	for rows.Next() {
+		if err := rows.Scan(addrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			value := values[i]
+			if raw, ok := value.([]byte); ok {
+				value = append([]byte(nil), raw...)
+			}
+			row[column] = value
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scanTargets holds index paths of struct fields for each column of a result,
+// nil index means the column is discarded
+type scanTargets [][]int
+
+func newScanTargets(rows RowsScanner, t reflect.Type, strict bool) (scanTargets, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	byColumn := make(map[string][]int)
+	for _, f := range structFields(t) {
+		byColumn[f.column] = f.index
+	}
+
+	targets := make(scanTargets, len(columns))
+	for i, column := range columns {
+		index, ok := byColumn[column]
+		if !ok && strict {
+			return nil, fmt.Errorf("struct %s has no field for column %q", t, column)
+		}
+		targets[i] = index
+	}
+	return targets, nil
+}
+
+// addrs returns scan destinations inside of struct v, allocating
+// nil embedded struct pointers on the way
+func (st scanTargets) addrs(v reflect.Value) []interface{} {
+	addrs := make([]interface{}, len(st))
+	for i, index := range st {
+		if index == nil {
+			addrs[i] = new(interface{})
+			continue
+		}
+
+		f := v
+		for j, x := range index {
+			if j > 0 && f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					f.Set(reflect.New(f.Type().Elem()))
+				}
+				f = f.Elem()
+			}
+			f = f.Field(x)
+		}
+		addrs[i] = f.Addr().Interface()
+	}
+	return addrs
+}
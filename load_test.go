@@ -0,0 +1,115 @@
+package sqrl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loadBase struct {
+	ID int64 `db:"id"`
+}
+
+type LoadMeta struct {
+	Note string `db:"note"`
+}
+
+type loadUser struct {
+	loadBase
+	*LoadMeta
+	Name   string  `db:"name"`
+	Email  *string `db:"email"`
+	Age    int
+	Secret string `db:"-"`
+}
+
+func newLoadDB(columns []string, rows ...[]driver.Value) *sql.DB {
+	db, d := newStubDB()
+	d.columns = columns
+	d.rows = rows
+	return db
+}
+
+func TestSelectBuilderLoadAll(t *testing.T) {
+	db := newLoadDB(
+		[]string{"id", "name", "email", "Age", "note"},
+		[]driver.Value{int64(1), "foo", "foo@example.com", int64(30), "first"},
+		[]driver.Value{int64(2), "bar", nil, int64(40), "second"},
+	)
+	defer db.Close()
+
+	var users []loadUser
+	err := Select("id", "name", "email", "Age", "note").From("users").RunWith(db).LoadAll(&users)
+	assert.NoError(t, err)
+
+	email := "foo@example.com"
+	expected := []loadUser{
+		{loadBase: loadBase{ID: 1}, LoadMeta: &LoadMeta{Note: "first"}, Name: "foo", Email: &email, Age: 30},
+		{loadBase: loadBase{ID: 2}, LoadMeta: &LoadMeta{Note: "second"}, Name: "bar", Age: 40},
+	}
+	assert.Equal(t, expected, users)
+
+	var ptrs []*loadUser
+	err = Select("id").From("users").RunWith(newLoadDB([]string{"id"}, []driver.Value{int64(3)})).LoadAll(&ptrs)
+	assert.NoError(t, err)
+	assert.Equal(t, []*loadUser{{loadBase: loadBase{ID: 3}}}, ptrs)
+}
+
+func TestSelectBuilderLoadOne(t *testing.T) {
+	db := newLoadDB([]string{"id", "name"}, []driver.Value{int64(1), "foo"})
+	defer db.Close()
+
+	var user loadUser
+	err := Select("id", "name").From("users").RunWith(db).LoadOne(&user)
+	assert.NoError(t, err)
+	assert.Equal(t, loadUser{loadBase: loadBase{ID: 1}, Name: "foo"}, user)
+
+	err = Select("id").From("users").RunWith(newLoadDB([]string{"id"})).LoadOne(&user)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestSelectBuilderLoadStrict(t *testing.T) {
+	db := newLoadDB([]string{"id", "Secret"}, []driver.Value{int64(1), "hidden"})
+	defer db.Close()
+
+	var user loadUser
+	err := Select("id", "Secret").From("users").RunWith(db).LoadOne(&user)
+	assert.EqualError(t, err, `struct sqrl.loadUser has no field for column "Secret"`)
+
+	err = Select("id", "Secret").From("users").RunWith(db).LoadStrict(false).LoadOne(&user)
+	assert.NoError(t, err)
+	assert.Equal(t, loadUser{loadBase: loadBase{ID: 1}}, user)
+}
+
+func TestSelectBuilderLoadErrors(t *testing.T) {
+	var users []loadUser
+	assert.Equal(t, ErrRunnerNotSet, Select("id").From("users").LoadAll(&users))
+
+	var user loadUser
+	assert.EqualError(t, Select("id").LoadAll(user), "expected pointer to slice, got sqrl.loadUser")
+	assert.EqualError(t, Select("id").LoadAll(&[]int{}), "expected pointer to slice of structs, got *[]int")
+	assert.EqualError(t, Select("id").LoadOne(users), "expected pointer to struct, got []sqrl.loadUser")
+}
+
+func TestSelectBuilderLoadMaps(t *testing.T) {
+	db := newLoadDB(
+		[]string{"id", "name"},
+		[]driver.Value{int64(1), []byte("foo")},
+		[]driver.Value{int64(2), nil},
+	)
+	defer db.Close()
+
+	rows, err := Select("id", "name").From("users").RunWith(db).LoadMaps()
+	assert.NoError(t, err)
+
+	expected := []map[string]interface{}{
+		{"id": int64(1), "name": []byte("foo")},
+		{"id": int64(2), "name": nil},
+	}
+	assert.Equal(t, expected, rows)
+
+	_, err = Select("id").From("users").LoadMaps()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,234 @@
+package sqrl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MergeBuilder builds SQL MERGE statements.
+//
+// MERGE is supported by SQL Server, Oracle and PostgreSQL 15+.
+type MergeBuilder struct {
+	StatementBuilderType
+
+	target      string
+	source      interface{}
+	sourceAlias string
+	onParts     []Sqlizer
+	whens       []mergeWhen
+}
+
+// mergeWhen is a WHEN [NOT] MATCHED clause of MERGE statement.
+type mergeWhen struct {
+	matched    bool
+	action     string
+	setClauses []setClause
+}
+
+// NewMergeBuilder creates new instance of MergeBuilder
+func NewMergeBuilder(b StatementBuilderType) *MergeBuilder {
+	return &MergeBuilder{StatementBuilderType: b}
+}
+
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+//
+// Clauses are copied, but their args and nested builders (e.g. subqueries)
+// are shared between the original and the copy.
+func (b *MergeBuilder) Clone() *MergeBuilder {
+	c := *b
+	c.onParts = append([]Sqlizer(nil), b.onParts...)
+	c.whens = append([]mergeWhen(nil), b.whens...)
+	return &c
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *MergeBuilder) RunWith(runner BaseRunner) *MergeBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *MergeBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *MergeBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *MergeBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(b.runContext())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *MergeBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
+// query.
+func (b *MergeBuilder) PlaceholderFormat(f PlaceholderFormat) *MergeBuilder {
+	b.placeholderFormat = f
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *MergeBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *MergeBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
+	if len(b.target) == 0 {
+		err = fmt.Errorf("merge statements must specify a target table")
+		return
+	}
+	if b.source == nil {
+		err = fmt.Errorf("merge statements must specify a source with Using")
+		return
+	}
+	if len(b.onParts) == 0 {
+		err = fmt.Errorf("merge statements must have a join condition")
+		return
+	}
+	if len(b.whens) == 0 {
+		err = fmt.Errorf("merge statements must have at least one WHEN clause")
+		return
+	}
+
+	sql := &bytes.Buffer{}
+
+	sql.WriteString("MERGE INTO ")
+	sql.WriteString(b.target)
+
+	sourceSql, sourceArgs, err := operandToSql(b.source)
+	if err != nil {
+		return
+	}
+	sql.WriteString(" USING ")
+	sql.WriteString(sourceSql)
+	args = append(args, sourceArgs...)
+	if len(b.sourceAlias) > 0 {
+		sql.WriteString(" AS ")
+		sql.WriteString(b.sourceAlias)
+	}
+
+	sql.WriteString(" ON ")
+	args, err = appendToSql(b.onParts, sql, " AND ", args)
+	if err != nil {
+		return
+	}
+
+	for _, when := range b.whens {
+		args, err = when.appendToSql(sql, args)
+		if err != nil {
+			return
+		}
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+func (c mergeWhen) appendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	if c.matched {
+		io.WriteString(w, " WHEN MATCHED THEN ")
+	} else {
+		io.WriteString(w, " WHEN NOT MATCHED THEN ")
+	}
+
+	switch c.action {
+	case "DELETE":
+		io.WriteString(w, "DELETE")
+		return args, nil
+	case "UPDATE":
+		if len(c.setClauses) == 0 {
+			return nil, fmt.Errorf("merge when matched clause must have at least one column to set")
+		}
+		io.WriteString(w, "UPDATE SET ")
+		return appendSetClausesToSql(c.setClauses, w, args)
+	}
+
+	if len(c.setClauses) == 0 {
+		return nil, fmt.Errorf("merge when not matched clause must have at least one column to insert")
+	}
+	columns := make([]string, len(c.setClauses))
+	values := make([]string, len(c.setClauses))
+	for i, set := range c.setClauses {
+		columns[i] = set.column
+		if s, ok := set.value.(Sqlizer); ok {
+			valSql, valArgs, err := nestedToSql(s)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = valSql
+			args = append(args, valArgs...)
+		} else {
+			values[i] = "?"
+			args = append(args, set.value)
+		}
+	}
+	fmt.Fprintf(w, "INSERT (%s) VALUES (%s)", strings.Join(columns, ", "), strings.Join(values, ", "))
+	return args, nil
+}
+
+// Into sets the target table of the query.
+func (b *MergeBuilder) Into(target string) *MergeBuilder {
+	b.target = target
+	return b
+}
+
+// Using sets the source of rows merged into the target table. Source may be
+// a table name, a subquery or a Values list, its args are placed before args
+// of the other clauses. Alias is omitted if empty.
+//
+// Ex:
+//     Merge("users u").Using(Values(rows, "", "id", "name"), "s(id, name)")
+//     Merge("users u").Using(Select("id", "name").From("staging"), "s")
+func (b *MergeBuilder) Using(source interface{}, alias string) *MergeBuilder {
+	b.source = source
+	b.sourceAlias = alias
+	return b
+}
+
+// On adds a condition joining source rows to rows of the target table.
+// Multiple conditions are joined with AND.
+//
+// See SelectBuilder.Where for supported predicates.
+func (b *MergeBuilder) On(pred interface{}, args ...interface{}) *MergeBuilder {
+	b.onParts = append(b.onParts, newWherePart(pred, args...))
+	return b
+}
+
+// WhenMatched adds WHEN MATCHED THEN UPDATE SET clause assigning values of
+// the map to columns of matched rows in key order.
+func (b *MergeBuilder) WhenMatched(clauses map[string]interface{}) *MergeBuilder {
+	b.whens = append(b.whens, mergeWhen{matched: true, action: "UPDATE", setClauses: setClausesFromMap(clauses)})
+	return b
+}
+
+// WhenMatchedDelete adds WHEN MATCHED THEN DELETE clause removing matched rows.
+func (b *MergeBuilder) WhenMatchedDelete() *MergeBuilder {
+	b.whens = append(b.whens, mergeWhen{matched: true, action: "DELETE"})
+	return b
+}
+
+// WhenNotMatched adds WHEN NOT MATCHED THEN INSERT clause inserting values
+// of the map into columns in key order for source rows without a match.
+func (b *MergeBuilder) WhenNotMatched(values map[string]interface{}) *MergeBuilder {
+	b.whens = append(b.whens, mergeWhen{action: "INSERT", setClauses: setClausesFromMap(values)})
+	return b
+}
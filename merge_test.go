@@ -0,0 +1,72 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBuilderToSql(t *testing.T) {
+	rows := [][]interface{}{{1, "foo"}, {2, "bar"}}
+	sql, args, err := Merge("users u").
+		Using(Values(rows, "", "id", "name"), "s(id, name)").
+		On("u.id = s.id").
+		WhenMatched(map[string]interface{}{"name": Expr("s.name"), "updated": true}).
+		WhenNotMatched(map[string]interface{}{"id": Expr("s.id"), "name": Expr("s.name"), "updated": false}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "MERGE INTO users u USING (VALUES ($1,$2),($3,$4)) AS s(id, name) ON u.id = s.id " +
+		"WHEN MATCHED THEN UPDATE SET name = s.name, updated = $5 " +
+		"WHEN NOT MATCHED THEN INSERT (id, name, updated) VALUES (s.id, s.name, $6)"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, "foo", 2, "bar", true, false}, args)
+}
+
+func TestMergeBuilderSubquery(t *testing.T) {
+	sql, args, err := Merge("users u").
+		Using(Select("id").From("bans").Where("until > ?", "2020-01-01"), "b").
+		On("u.id = b.id").
+		On("u.role <> ?", "admin").
+		WhenMatchedDelete().
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "MERGE INTO users u USING (SELECT id FROM bans WHERE until > ?) AS b " +
+		"ON u.id = b.id AND u.role <> ? WHEN MATCHED THEN DELETE"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"2020-01-01", "admin"}, args)
+}
+
+func TestMergeBuilderToSqlErr(t *testing.T) {
+	_, _, err := Merge("").ToSql()
+	assert.EqualError(t, err, "merge statements must specify a target table")
+
+	_, _, err = Merge("t").ToSql()
+	assert.EqualError(t, err, "merge statements must specify a source with Using")
+
+	_, _, err = Merge("t").Using("s", "").ToSql()
+	assert.EqualError(t, err, "merge statements must have a join condition")
+
+	_, _, err = Merge("t").Using("s", "").On("t.id = s.id").ToSql()
+	assert.EqualError(t, err, "merge statements must have at least one WHEN clause")
+
+	_, _, err = Merge("t").Using("s", "").On("t.id = s.id").WhenMatched(nil).ToSql()
+	assert.EqualError(t, err, "merge when matched clause must have at least one column to set")
+
+	_, _, err = Merge("t").Using("s", "").On("t.id = s.id").WhenNotMatched(nil).ToSql()
+	assert.EqualError(t, err, "merge when not matched clause must have at least one column to insert")
+}
+
+func TestMergeBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Merge("t").Using("s", "").On("t.id = s.id").WhenMatchedDelete()
+
+	_, err := b.Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
+	_, err = b.RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN DELETE", db.LastExecSql)
+}
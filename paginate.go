@@ -0,0 +1,155 @@
+package sqrl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// keysetExpr selects rows following the cursor in the given ordering
+type keysetExpr struct {
+	orderBy []string
+	cursor  []interface{}
+}
+
+// Keyset builds a condition selecting rows that come after cursor when
+// sorted by orderBy, for use with keyset (seek method) pagination.
+// Each orderBy item is a column optionally followed by ASC or DESC,
+// cursor holds values of these columns in the last row of previous page.
+//
+// Ex:
+//     Keyset([]string{"created_at", "id"}, []interface{}{t, 10})
+//     // (created_at, id) > (?, ?)
+//     Keyset([]string{"created_at DESC", "id"}, []interface{}{t, 10})
+//     // (created_at < ? OR (created_at = ? AND id > ?))
+func Keyset(orderBy []string, cursor []interface{}) Sqlizer {
+	return keysetExpr{orderBy: orderBy, cursor: cursor}
+}
+
+func (e keysetExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.orderBy) == 0 {
+		err = fmt.Errorf("keyset requires at least one order column")
+		return
+	}
+	if len(e.orderBy) != len(e.cursor) {
+		err = fmt.Errorf("keyset got %d cursor values for %d order columns", len(e.cursor), len(e.orderBy))
+		return
+	}
+
+	columns := make([]string, len(e.orderBy))
+	ops := make([]string, len(e.orderBy))
+	for i, orderBy := range e.orderBy {
+		var desc bool
+		columns[i], desc, err = parsePageColumn(orderBy)
+		if err != nil {
+			return
+		}
+		ops[i] = ">"
+		if desc {
+			ops[i] = "<"
+		}
+	}
+
+	if len(columns) == 1 {
+		return fmt.Sprintf("%s %s ?", columns[0], ops[0]), e.cursor, nil
+	}
+
+	sameDirection := true
+	for _, op := range ops[1:] {
+		sameDirection = sameDirection && op == ops[0]
+	}
+	if sameDirection {
+		return tupleExpr{columns: columns, operator: ops[0], values: e.cursor}.ToSql()
+	}
+
+	// (a op ? OR (a = ? AND b op ?) OR (a = ? AND b = ? AND c op ?) ...)
+	terms := make([]string, len(columns))
+	for i := range columns {
+		conds := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conds = append(conds, columns[j]+" = ?")
+			args = append(args, e.cursor[j])
+		}
+		conds = append(conds, fmt.Sprintf("%s %s ?", columns[i], ops[i]))
+		args = append(args, e.cursor[i])
+
+		terms[i] = strings.Join(conds, " AND ")
+		if i > 0 {
+			terms[i] = "(" + terms[i] + ")"
+		}
+	}
+	sql = "(" + strings.Join(terms, " OR ") + ")"
+	return
+}
+
+// parsePageColumn splits order column into column name and direction
+func parsePageColumn(orderBy string) (column string, desc bool, err error) {
+	fields := strings.Fields(orderBy)
+	switch {
+	case len(fields) == 1:
+		return fields[0], false, nil
+	case len(fields) == 2 && strings.EqualFold(fields[1], "ASC"):
+		return fields[0], false, nil
+	case len(fields) == 2 && strings.EqualFold(fields[1], "DESC"):
+		return fields[0], true, nil
+	}
+	return "", false, fmt.Errorf("unsupported keyset ordering %q", orderBy)
+}
+
+// Paginate orders the query by orderBy, limits it to limit rows and, if cursor
+// is not empty, selects only rows following the cursor. See Keyset.
+//
+// Cursor of the next page is returned by LoadPage.
+//
+// Ex:
+//     Select("*").From("posts").Paginate([]string{"created_at DESC", "id DESC"}, cursor, 20)
+func (b *SelectBuilder) Paginate(orderBy []string, cursor []interface{}, limit uint64) *SelectBuilder {
+	b.pageColumns = orderBy
+	if len(cursor) > 0 {
+		b.Where(Keyset(orderBy, cursor))
+	}
+	return b.OrderBy(orderBy...).Limit(limit)
+}
+
+// LoadPage loads rows of a query set up by Paginate into dest like LoadAll
+// and returns the cursor of the next page, taken from the last loaded row.
+// If the page is not full, there are no more rows and nil cursor is returned.
+//
+// Order columns are matched to struct fields by name without table qualifier,
+// e.g. "p.created_at DESC" is read from field tagged `db:"created_at"`.
+func (b *SelectBuilder) LoadPage(dest interface{}) (next []interface{}, err error) {
+	if len(b.pageColumns) == 0 {
+		return nil, fmt.Errorf("LoadPage requires Paginate to be called first")
+	}
+	if err = b.LoadAll(dest); err != nil {
+		return nil, err
+	}
+
+	slice := reflect.ValueOf(dest).Elem()
+	if slice.Len() == 0 || (b.limitValid && uint64(slice.Len()) < b.limit) {
+		return nil, nil
+	}
+
+	last := reflect.Indirect(slice.Index(slice.Len() - 1))
+	byColumn := make(map[string][]int)
+	for _, f := range structFields(last.Type()) {
+		byColumn[f.column] = f.index
+	}
+
+	next = make([]interface{}, len(b.pageColumns))
+	for i, orderBy := range b.pageColumns {
+		column, _, err := parsePageColumn(orderBy)
+		if err != nil {
+			return nil, err
+		}
+		if dot := strings.LastIndex(column, "."); dot >= 0 {
+			column = column[dot+1:]
+		}
+		index, ok := byColumn[column]
+		if !ok {
+			return nil, fmt.Errorf("struct %s has no field for page column %q", last.Type(), column)
+		}
+		next[i] = structFieldValue(last, index)
+	}
+	return next, nil
+}
@@ -0,0 +1,86 @@
+package sqrl
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyset(t *testing.T) {
+	sql, args, err := Keyset([]string{"id"}, []interface{}{10}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "id > ?", sql)
+	assert.Equal(t, []interface{}{10}, args)
+
+	sql, args, err = Keyset([]string{"created_at DESC", "id desc"}, []interface{}{"2020-01-01", 10}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at, id) < (?, ?)", sql)
+	assert.Equal(t, []interface{}{"2020-01-01", 10}, args)
+
+	sql, args, err = Keyset([]string{"score DESC", "name", "id ASC"}, []interface{}{5, "foo", 10}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(score < ? OR (score = ? AND name > ?) OR (score = ? AND name = ? AND id > ?))", sql)
+	assert.Equal(t, []interface{}{5, 5, "foo", 5, "foo", 10}, args)
+}
+
+func TestKeysetErrors(t *testing.T) {
+	_, _, err := Keyset(nil, nil).ToSql()
+	assert.EqualError(t, err, "keyset requires at least one order column")
+
+	_, _, err = Keyset([]string{"a", "b"}, []interface{}{1}).ToSql()
+	assert.EqualError(t, err, "keyset got 1 cursor values for 2 order columns")
+
+	_, _, err = Keyset([]string{"a NULLS LAST"}, []interface{}{1}).ToSql()
+	assert.EqualError(t, err, `unsupported keyset ordering "a NULLS LAST"`)
+}
+
+func TestSelectBuilderPaginate(t *testing.T) {
+	sql, args, err := Select("*").From("posts").
+		Paginate([]string{"id"}, nil, 20).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM posts ORDER BY id LIMIT 20", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = Select("*").From("posts").
+		Where("author_id = ?", 1).
+		Paginate([]string{"created_at DESC", "id"}, []interface{}{"2020-01-01", 10}, 20).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM posts WHERE author_id = $1 AND (created_at < $2 OR (created_at = $3 AND id > $4)) " +
+		"ORDER BY created_at DESC, id LIMIT 20"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, "2020-01-01", "2020-01-01", 10}, args)
+}
+
+func TestSelectBuilderLoadPage(t *testing.T) {
+	db := newLoadDB(
+		[]string{"id", "name"},
+		[]driver.Value{int64(1), "foo"},
+		[]driver.Value{int64(2), "bar"},
+	)
+	defer db.Close()
+
+	var users []loadUser
+	next, err := Select("id", "name").From("users u").
+		Paginate([]string{"name", "u.id"}, nil, 2).
+		RunWith(db).
+		LoadPage(&users)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, []interface{}{"bar", int64(2)}, next)
+
+	next, err = Select("id", "name").From("users u").
+		Paginate([]string{"name", "u.id"}, next, 3).
+		RunWith(db).
+		LoadPage(&users)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Nil(t, next)
+
+	_, err = Select("id").From("users").RunWith(db).LoadPage(&users)
+	assert.EqualError(t, err, "LoadPage requires Paginate to be called first")
+}
@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 )
@@ -14,12 +15,28 @@ func newPart(pred interface{}, args ...interface{}) Sqlizer {
 	return &part{pred, args}
 }
 
+// rawSqlizer is implemented by builders that are able to render themselves
+// without replacing placeholders, so they can be nested into other queries.
+type rawSqlizer interface {
+	toSqlRaw() (string, []interface{}, error)
+}
+
+// nestedToSql builds SQL of s which is going to be embedded into another query.
+// Placeholders of nested builders are left intact so that the outer query can
+// number them correctly.
+func nestedToSql(s Sqlizer) (string, []interface{}, error) {
+	if raw, ok := s.(rawSqlizer); ok {
+		return raw.toSqlRaw()
+	}
+	return s.ToSql()
+}
+
 func (p part) ToSql() (sql string, args []interface{}, err error) {
 	switch pred := p.pred.(type) {
 	case nil:
 		// no-op
 	case Sqlizer:
-		sql, args, err = pred.ToSql()
+		sql, args, err = nestedToSql(pred)
 	case string:
 		sql = pred
 		args = p.args
@@ -30,7 +47,8 @@ func (p part) ToSql() (sql string, args []interface{}, err error) {
 }
 
 func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) ([]interface{}, error) {
-	for i, p := range parts {
+	written := false
+	for _, p := range parts {
 		partSql, partArgs, err := p.ToSql()
 		if err != nil {
 			return nil, err
@@ -38,7 +56,7 @@ func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) (
 			continue
 		}
 
-		if i > 0 {
+		if written {
 			_, err := io.WriteString(w, sep)
 			if err != nil {
 				return nil, err
@@ -49,7 +67,26 @@ func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) (
 		if err != nil {
 			return nil, err
 		}
+		written = true
 		args = append(args, partArgs...)
 	}
 	return args, nil
 }
+
+// appendClauseToSql writes keyword followed by parts joined with sep.
+// Nothing is written if all parts are empty.
+func appendClauseToSql(parts []Sqlizer, w io.Writer, keyword, sep string, args []interface{}) ([]interface{}, error) {
+	buf := &bytes.Buffer{}
+	args, err := appendToSql(parts, buf, sep, args)
+	if err != nil || buf.Len() == 0 {
+		return args, err
+	}
+
+	if _, err := io.WriteString(w, keyword); err != nil {
+		return nil, err
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
@@ -30,6 +30,110 @@ func (a array) ToSql() (string, []interface{}, error) {
 	return "?", []interface{}{buf.String()}, nil
 }
 
+// ArrayContains is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.ArrayContains{Column: "tags", Value: pq.Array(tags)}) == "tags @> ?"
+//
+// Value is passed as a single arg unless it is a sqrl.Sqlizer like Array
+type ArrayContains struct {
+	Column string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (ac ArrayContains) ToSql() (string, []interface{}, error) {
+	return binaryOp(ac.Column, "@>", ac.Value)
+}
+
+// ArrayContainedBy is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.ArrayContainedBy{Column: "tags", Value: pq.Array(tags)}) == "tags <@ ?"
+type ArrayContainedBy struct {
+	Column string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (acb ArrayContainedBy) ToSql() (string, []interface{}, error) {
+	return binaryOp(acb.Column, "<@", acb.Value)
+}
+
+// ArrayOverlap is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.ArrayOverlap{Column: "tags", Value: pq.Array(tags)}) == "tags && ?"
+type ArrayOverlap struct {
+	Column string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (ao ArrayOverlap) ToSql() (string, []interface{}, error) {
+	return binaryOp(ao.Column, "&&", ao.Value)
+}
+
+// Any is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.Any{Column: "id", Value: pq.Array(ids)}) == "id = ANY(?)"
+//
+// Op defaults to "=", Value is passed as a single arg
+type Any struct {
+	Column string
+	Op     string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (a Any) ToSql() (string, []interface{}, error) {
+	return quantifiedOp(a.Column, a.Op, "ANY", a.Value)
+}
+
+// All is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.All{Column: "price", Op: ">", Value: pq.Array(prices)}) == "price > ALL(?)"
+//
+// Op defaults to "=", Value is passed as a single arg
+type All struct {
+	Column string
+	Op     string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (a All) ToSql() (string, []interface{}, error) {
+	return quantifiedOp(a.Column, a.Op, "ALL", a.Value)
+}
+
+func quantifiedOp(column, op, quantifier string, value interface{}) (string, []interface{}, error) {
+	if op == "" {
+		op = "="
+	}
+
+	sql, args := "?", []interface{}{value}
+	if s, ok := value.(sqrl.Sqlizer); ok {
+		var err error
+		sql, args, err = s.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return fmt.Sprintf("%s %s %s(%s)", column, op, quantifier, sql), args, nil
+}
+
+// binaryOp renders "column op ?" with value as a single arg,
+// sqrl.Sqlizer values are rendered in place of the placeholder
+func binaryOp(column, op string, value interface{}) (string, []interface{}, error) {
+	if s, ok := value.(sqrl.Sqlizer); ok {
+		sql, args, err := s.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s %s %s", column, op, sql), args, nil
+	}
+
+	return fmt.Sprintf("%s %s ?", column, op), []interface{}{value}, nil
+}
+
 type marshaler func(reflect.Value, *bytes.Buffer)
 
 var marshalers = map[reflect.Kind]marshaler{
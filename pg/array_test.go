@@ -5,7 +5,7 @@ import (
 	"testing"
 
 	"github.com/elgris/sqrl"
-	"github.com/elgris/sqrl/pg"
+	"github.com/ajpetersons/sqrl/pg"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +72,57 @@ func ExampleArray() {
 	// INSERT INTO posts (content,tags) VALUES ($1,$2)
 	// [Lorem Ipsum {"foo","bar"}]
 }
+
+func TestArrayOperators(t *testing.T) {
+	tags := []string{"go", "sql"}
+
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.ArrayContains{Column: "tags", Value: tags}, "tags @> ?", []interface{}{tags}},
+		{pg.ArrayContainedBy{Column: "tags", Value: tags}, "tags <@ ?", []interface{}{tags}},
+		{pg.ArrayOverlap{Column: "tags", Value: tags}, "tags && ?", []interface{}{tags}},
+		{pg.ArrayContains{Column: "tags", Value: pg.Array(tags)}, "tags @> ?", []interface{}{`{"go","sql"}`}},
+		{
+			sqrl.And{pg.ArrayOverlap{Column: "tags", Value: tags}, sqrl.Eq{"published": true}},
+			"(tags && ? AND published = ?)",
+			[]interface{}{tags, true},
+		},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+
+	_, _, err := pg.ArrayContains{Column: "tags", Value: pg.Array(42)}.ToSql()
+	assert.Error(t, err)
+}
+
+func TestAnyAll(t *testing.T) {
+	ids := []int{1, 2, 3}
+
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.Any{Column: "id", Value: ids}, "id = ANY(?)", []interface{}{ids}},
+		{pg.Any{Column: "id", Op: "<>", Value: ids}, "id <> ANY(?)", []interface{}{ids}},
+		{pg.All{Column: "price", Op: ">", Value: ids}, "price > ALL(?)", []interface{}{ids}},
+		{pg.All{Column: "id", Value: pg.Array(ids)}, "id = ALL(?)", []interface{}{"{1,2,3}"}},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
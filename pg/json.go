@@ -1,8 +1,10 @@
 package pg
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/elgris/sqrl"
 )
@@ -37,3 +39,64 @@ func (jo jsonOp) ToSql() (string, []interface{}, error) {
 
 	return fmt.Sprintf("?::%s", jo.tpe), []interface{}{string(v)}, nil
 }
+
+// JSONPath is a JSON path extraction expression built by JSONExtract
+type JSONPath struct {
+	column string
+	path   []string
+	text   bool
+}
+
+// JSONExtract builds JSON path extraction from column using "->" operator
+// Ex:
+//     pg.JSONExtract("data", "a", "b") == "data->'a'->'b'"
+//
+// It can be used as a column or inside of Where/Having methods
+func JSONExtract(column string, path ...string) JSONPath {
+	return JSONPath{column: column, path: path}
+}
+
+// Text makes the last path segment extract text value using "->>" operator
+// Ex:
+//     pg.JSONExtract("data", "a", "b").Text() == "data->'a'->>'b'"
+func (jp JSONPath) Text() JSONPath {
+	jp.text = true
+	return jp
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (jp JSONPath) ToSql() (string, []interface{}, error) {
+	if len(jp.path) == 0 {
+		return "", nil, fmt.Errorf("JSON path for %s is empty", jp.column)
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(jp.column)
+	for i, key := range jp.path {
+		if jp.text && i == len(jp.path)-1 {
+			buf.WriteString("->>")
+		} else {
+			buf.WriteString("->")
+		}
+		buf.WriteString("'")
+		buf.WriteString(strings.Replace(key, "'", "''", -1))
+		buf.WriteString("'")
+	}
+
+	return buf.String(), nil, nil
+}
+
+// JSONContains is syntactic sugar for use with Where/Having methods.
+// Ex:
+//     .Where(pg.JSONContains{Column: "data", Value: pg.JSONB(v)}) == "data @> ?::jsonb"
+//
+// Value is passed as a single arg unless it is a sqrl.Sqlizer like JSONB
+type JSONContains struct {
+	Column string
+	Value  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (jc JSONContains) ToSql() (string, []interface{}, error) {
+	return binaryOp(jc.Column, "@>", jc.Value)
+}
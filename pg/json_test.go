@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ajpetersons/sqrl/pg"
 	"github.com/elgris/sqrl"
-	"github.com/elgris/sqrl/pg"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -71,3 +71,43 @@ func ExampleJSONB() {
 	// INSERT INTO posts (content,tags) VALUES ($1,$2::jsonb)
 	// [Lorem Ipsum ["foo","bar"]]
 }
+
+func TestJSONExtract(t *testing.T) {
+	sql, args, err := pg.JSONExtract("data", "address", "city").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data->'address'->'city'", sql)
+	assert.Empty(t, args)
+
+	sql, _, err = pg.JSONExtract("data", "address", "city").Text().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data->'address'->>'city'", sql)
+
+	sql, _, err = pg.JSONExtract("data", "o'brien").Text().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data->>'o''brien'", sql)
+
+	_, _, err = pg.JSONExtract("data").ToSql()
+	assert.Error(t, err)
+}
+
+func TestJSONExtractColumn(t *testing.T) {
+	sql, _, err := sqrl.Select("id").
+		Column(pg.JSONExtract("data", "address", "city").Text()).
+		From("users").
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, data->'address'->>'city' FROM users", sql)
+}
+
+func TestJSONContains(t *testing.T) {
+	sql, args, err := pg.JSONContains{Column: "data", Value: `{"k":"v"}`}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data @> ?", sql)
+	assert.Equal(t, []interface{}{`{"k":"v"}`}, args)
+
+	sql, args, err = pg.JSONContains{Column: "data", Value: pg.JSONB(map[string]string{"k": "v"})}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "data @> ?::jsonb", sql)
+	assert.Equal(t, []interface{}{`{"k":"v"}`}, args)
+}
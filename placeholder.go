@@ -2,7 +2,9 @@ package sqrl
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -14,14 +16,58 @@ type PlaceholderFormat interface {
 	ReplacePlaceholders(sql string) (string, error)
 }
 
+// ArgsPlaceholderFormat is the interface that wraps the ReplacePlaceholdersArgs method.
+//
+// ReplacePlaceholdersArgs is used by builders instead of ReplacePlaceholders
+// when placeholders depend on bound args, it returns args to be passed
+// to the driver along with the SQL statement.
+type ArgsPlaceholderFormat interface {
+	PlaceholderFormat
+	ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error)
+}
+
 var (
 	// Question is a PlaceholderFormat instance that leaves placeholders as
 	// question marks.
+	// Building a query with sql.NamedArg args fails with this format.
 	Question = questionFormat{}
 
 	// Dollar is a PlaceholderFormat instance that replaces placeholders with
 	// dollar-prefixed positional placeholders (e.g. $1, $2, $3).
+	// Building a query with sql.NamedArg args fails with this format.
 	Dollar = dollarFormat{}
+
+	// DollarDedup is a PlaceholderFormat instance that works like Dollar, but
+	// binds equal args only once and reuses their placeholder (e.g. $1 AND $1).
+	// Only args of basic types (numbers, strings and booleans) are compared.
+	// Building a query with sql.NamedArg args fails with this format.
+	DollarDedup = dollarDedupFormat{}
+
+	// Colon is a PlaceholderFormat instance that replaces placeholders with
+	// colon-prefixed positional placeholders (e.g. :1, :2, :3).
+	// Building a query with sql.NamedArg args fails with this format.
+	Colon = colonFormat{}
+
+	// AtP is a PlaceholderFormat instance that replaces placeholders with
+	// "@p"-prefixed positional placeholders (e.g. @p1, @p2, @p3).
+	// Building a query with sql.NamedArg args fails with this format.
+	AtP = atpFormat{}
+
+	// Named is a PlaceholderFormat instance that replaces placeholders with
+	// colon-prefixed named placeholders (e.g. :arg1, :arg2, :arg3).
+	// Args passed as sql.NamedArg keep their names, other args are
+	// wrapped into sql.NamedArg named after their position. Building a query
+	// fails if a sql.NamedArg is named like a positional arg (e.g. arg2).
+	// A sql.NamedArg referenced in the query by name (e.g. @n) is not bound
+	// to a placeholder.
+	Named = namedFormat{}
+
+	// Inline is a PlaceholderFormat instance that replaces placeholders with
+	// literal values of args formatted the same way as by Debug, so that
+	// statements have no args. It is meant for trusted values in statements
+	// which cannot have parameters (e.g. DDL), never use it with user input.
+	// Building fails for args database/sql cannot convert (e.g. slices).
+	Inline = inlineFormat{}
 )
 
 type questionFormat struct{}
@@ -30,6 +76,10 @@ func (_ questionFormat) ReplacePlaceholders(sql string) (string, error) {
 	return sql, nil
 }
 
+func (f questionFormat) ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	return replacePositionalArgs(f, sql, args)
+}
+
 type dollarFormat struct{}
 
 func (_ dollarFormat) ReplacePlaceholders(sql string) (string, error) {
@@ -39,6 +89,294 @@ func (_ dollarFormat) ReplacePlaceholders(sql string) (string, error) {
 	})
 }
 
+func (f dollarFormat) ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	return replacePositionalArgs(f, sql, args)
+}
+
+type dollarDedupFormat struct{}
+
+func (_ dollarDedupFormat) ReplacePlaceholders(sql string) (string, error) {
+	return Dollar.ReplacePlaceholders(sql)
+}
+
+func (_ dollarDedupFormat) ReplacePlaceholdersArgs(sqlStr string, args []interface{}) (string, []interface{}, error) {
+	deduped := make([]interface{}, 0, len(args))
+	positions := make(map[interface{}]int, len(args))
+
+	n := 0
+	sqlStr, err := replacePlaceholders(sqlStr, func(buf *bytes.Buffer, i int) error {
+		if i > len(args) {
+			return fmt.Errorf("not enough args for placeholder %d, got %d", i, len(args))
+		}
+		n = i
+
+		arg := args[i-1]
+		if named, ok := arg.(sql.NamedArg); ok {
+			return fmt.Errorf("named arg %s cannot be used with positional placeholder format", named.Name)
+		}
+
+		if isBasicValue(arg) {
+			if pos, ok := positions[arg]; ok {
+				fmt.Fprintf(buf, "$%d", pos)
+				return nil
+			}
+			positions[arg] = len(deduped) + 1
+		}
+		deduped = append(deduped, arg)
+		fmt.Fprintf(buf, "$%d", len(deduped))
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if n != len(args) {
+		return "", nil, fmt.Errorf("got %d args for %d placeholders", len(args), n)
+	}
+	return sqlStr, deduped, nil
+}
+
+// isBasicValue reports whether v is a number, string or boolean, which can be
+// safely compared for equality
+func isBasicValue(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+type colonFormat struct{}
+
+func (_ colonFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		fmt.Fprintf(buf, ":%d", i)
+		return nil
+	})
+}
+
+func (f colonFormat) ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	return replacePositionalArgs(f, sql, args)
+}
+
+type atpFormat struct{}
+
+func (_ atpFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		fmt.Fprintf(buf, "@p%d", i)
+		return nil
+	})
+}
+
+func (f atpFormat) ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	return replacePositionalArgs(f, sql, args)
+}
+
+type namedFormat struct{}
+
+func (_ namedFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		fmt.Fprintf(buf, ":arg%d", i)
+		return nil
+	})
+}
+
+func (_ namedFormat) ReplacePlaceholdersArgs(sqlStr string, args []interface{}) (string, []interface{}, error) {
+	return replaceNamedArgs(":", sqlStr, args)
+}
+
+// replaceNamedArgs replaces placeholders with named placeholders starting
+// with prefix, wrapping positional args into sql.NamedArg named after their
+// position. Names of sql.NamedArg args must not clash with these names.
+//
+// sql.NamedArg args referenced in the statement by name (e.g. @n or :n) are
+// passed through as is, the rest of args are bound to placeholders in order.
+func replaceNamedArgs(prefix string, sqlStr string, args []interface{}) (string, []interface{}, error) {
+	all := make([]sql.NamedArg, len(args))
+	bound := make([]sql.NamedArg, 0, len(args))
+	names := make(map[string]bool, len(args))
+	for i, arg := range args {
+		named, ok := arg.(sql.NamedArg)
+		if ok {
+			names[named.Name] = true
+			all[i] = named
+			if !referencesName(sqlStr, named.Name) {
+				bound = append(bound, named)
+			}
+			continue
+		}
+		all[i] = sql.Named(fmt.Sprintf("arg%d", len(bound)+1), arg)
+		bound = append(bound, all[i])
+	}
+
+	for i, arg := range all {
+		if _, ok := args[i].(sql.NamedArg); !ok && names[arg.Name] {
+			return "", nil, fmt.Errorf("named arg %s clashes with name of positional arg", arg.Name)
+		}
+	}
+
+	n := 0
+	sqlStr, err := replacePlaceholders(sqlStr, func(buf *bytes.Buffer, i int) error {
+		if i > len(bound) {
+			return fmt.Errorf("not enough args for placeholder %d, got %d", i, len(bound))
+		}
+		n = i
+		buf.WriteString(prefix)
+		buf.WriteString(bound[i-1].Name)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if n != len(bound) {
+		return "", nil, fmt.Errorf("got %d args for %d placeholders", len(bound), n)
+	}
+
+	named := make([]interface{}, 0, len(all))
+	seen := make(map[string]interface{}, len(all))
+	for _, arg := range all {
+		if value, ok := seen[arg.Name]; ok {
+			if !reflect.DeepEqual(value, arg.Value) {
+				return "", nil, fmt.Errorf("conflicting values for named arg %s", arg.Name)
+			}
+			continue
+		}
+		seen[arg.Name] = arg.Value
+		named = append(named, arg)
+	}
+	return sqlStr, named, nil
+}
+
+// referencesName reports whether sqlStr refers to named arg by its name,
+// i.e. contains @name or :name not followed by other identifier characters
+func referencesName(sqlStr, name string) bool {
+	for i := 0; i < len(sqlStr); i++ {
+		c := sqlStr[i]
+		if (c != '@' && c != ':') || (i > 0 && sqlStr[i-1] == c) {
+			continue
+		}
+		if !strings.HasPrefix(sqlStr[i+1:], name) {
+			continue
+		}
+		end := i + 1 + len(name)
+		if end == len(sqlStr) || !isIdentChar(sqlStr[end]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentChar reports whether c can be a part of unquoted identifier
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+type inlineFormat struct{}
+
+func (f inlineFormat) ReplacePlaceholders(sql string) (string, error) {
+	sql, _, err := f.ReplacePlaceholdersArgs(sql, nil)
+	return sql, err
+}
+
+func (_ inlineFormat) ReplacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	n := 0
+	sql, err := replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		if i > len(args) {
+			return fmt.Errorf("not enough args for placeholder %d, got %d", i, len(args))
+		}
+		lit, err := literalValue(args[i-1])
+		if err != nil {
+			return err
+		}
+		n = i
+		buf.WriteString(lit)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if n != len(args) {
+		return "", nil, fmt.Errorf("got %d args for %d placeholders", len(args), n)
+	}
+	return sql, nil, nil
+}
+
+// ToSqlNamed builds the query into a SQL string with named placeholders
+// and named args. Placeholder format of the query is ignored, like of queries
+// nested into other ones.
+//
+// Ex:
+//     sql, args, err := ToSqlNamed(Select("*").From("users").Where("id = ?", sql.Named("id", 1)))
+//     // SELECT * FROM users WHERE id = :id
+func ToSqlNamed(s Sqlizer) (string, []sql.NamedArg, error) {
+	sqlStr, args, err := nestedToSql(s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlStr, args, err = Named.ReplacePlaceholdersArgs(sqlStr, args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sqlStr, toNamedArgs(args), nil
+}
+
+// ToNamed converts statement with question mark placeholders to named
+// placeholders prefixed with @ (e.g. @arg1, @arg2) and args to sql.NamedArg,
+// which is useful to pass statements built with Question format to drivers
+// supporting only named args. Args passed as sql.NamedArg keep their names.
+//
+// Ex:
+//     sql, args, err := ToNamed("x = ? AND y = ??", []interface{}{1})
+//     // x = @arg1 AND y = ?
+func ToNamed(sqlStr string, args []interface{}) (string, []sql.NamedArg, error) {
+	sqlStr, args, err := replaceNamedArgs("@", sqlStr, args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sqlStr, toNamedArgs(args), nil
+}
+
+// toNamedArgs converts args returned by replaceNamedArgs to sql.NamedArg
+func toNamedArgs(args []interface{}) []sql.NamedArg {
+	named := make([]sql.NamedArg, len(args))
+	for i, arg := range args {
+		named[i] = arg.(sql.NamedArg)
+	}
+	return named
+}
+
+// replacePlaceholdersArgs replaces placeholders with given format,
+// using bound args if format needs them
+func replacePlaceholdersArgs(f PlaceholderFormat, sql string, args []interface{}) (string, []interface{}, error) {
+	if af, ok := f.(ArgsPlaceholderFormat); ok {
+		return af.ReplacePlaceholdersArgs(sql, args)
+	}
+
+	sql, err := f.ReplacePlaceholders(sql)
+	return sql, args, err
+}
+
+// replacePositionalArgs replaces placeholders with positional format f,
+// named args cannot be bound to positional placeholders
+func replacePositionalArgs(f PlaceholderFormat, sqlStr string, args []interface{}) (string, []interface{}, error) {
+	for _, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			return "", nil, fmt.Errorf("named arg %s cannot be used with positional placeholder format", named.Name)
+		}
+	}
+
+	sqlStr, err := f.ReplacePlaceholders(sqlStr)
+	return sqlStr, args, err
+}
+
 // Placeholders returns a string with count ? placeholders joined with commas.
 func Placeholders(count int) string {
 	if count < 1 {
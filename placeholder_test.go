@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"database/sql"
 	"strings"
 	"testing"
 
@@ -19,6 +20,222 @@ func TestDollar(t *testing.T) {
 	assert.Equal(t, "x = $1 AND y = $2", s)
 }
 
+func TestColon(t *testing.T) {
+	sql := "x = ? AND y = ?"
+	s, _ := Colon.ReplacePlaceholders(sql)
+	assert.Equal(t, "x = :1 AND y = :2", s)
+}
+
+func TestColonEscape(t *testing.T) {
+	sql, args, err := StatementBuilder.PlaceholderFormat(Colon).
+		Select("id").
+		From("t").
+		Where("note = 'why??' AND id = ?", 1).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE note = 'why?' AND id = :1", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestAtP(t *testing.T) {
+	sql := "SELECT ?, ?"
+	s, _ := AtP.ReplacePlaceholders(sql)
+	assert.Equal(t, "SELECT @p1, @p2", s)
+
+	s, _ = AtP.ReplacePlaceholders("SELECT ?? FROM t WHERE x = ?")
+	assert.Equal(t, "SELECT ? FROM t WHERE x = @p1", s)
+}
+
+func TestNamed(t *testing.T) {
+	sql := "x = ? AND y = ?"
+	s, _ := Named.ReplacePlaceholders(sql)
+	assert.Equal(t, "x = :arg1 AND y = :arg2", s)
+}
+
+func TestNamedArgs(t *testing.T) {
+	s, args, err := Named.ReplacePlaceholdersArgs(
+		"x = ? AND y = ? AND z = ? OR w = ?",
+		[]interface{}{1, sql.Named("y", 2), sql.Named("x", 3), sql.Named("y", 2)},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "x = :arg1 AND y = :y AND z = :x OR w = :y", s)
+
+	expectedArgs := []interface{}{sql.Named("arg1", 1), sql.Named("y", 2), sql.Named("x", 3)}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = Named.ReplacePlaceholdersArgs("x = ? AND y = ?", []interface{}{1, sql.Named("arg1", 1)})
+	assert.EqualError(t, err, "named arg arg1 clashes with name of positional arg")
+
+	_, _, err = Named.ReplacePlaceholdersArgs("x = ? AND y = ?", []interface{}{sql.Named("arg2", 1), 1})
+	assert.EqualError(t, err, "named arg arg2 clashes with name of positional arg")
+
+	_, _, err = Named.ReplacePlaceholdersArgs("x = ? AND y = ?", []interface{}{sql.Named("x", 1), sql.Named("x", 2)})
+	assert.EqualError(t, err, "conflicting values for named arg x")
+}
+
+func TestNamedArgsMixed(t *testing.T) {
+	s, args, err := Select("*").
+		From("t").
+		Where("x = @n", sql.Named("n", 5)).
+		Where("y = ?", 2).
+		PlaceholderFormat(Named).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE x = @n AND y = :arg1", s)
+	assert.Equal(t, []interface{}{sql.Named("n", 5), sql.Named("arg1", 2)}, args)
+
+	_, _, err = Select("*").From("t").Where("y = ?", 2).Suffix("LIMIT 1", 9).PlaceholderFormat(Named).ToSql()
+	assert.EqualError(t, err, "got 2 args for 1 placeholders")
+
+	_, _, err = Named.ReplacePlaceholdersArgs("x = :m", []interface{}{sql.Named("n", 5)})
+	assert.EqualError(t, err, "got 1 args for 0 placeholders")
+}
+
+func TestNamedBuilder(t *testing.T) {
+	b := Select("*").
+		From("users").
+		Where("name = ?", sql.Named("name", "foo")).
+		Where("age > ?", 18).
+		PlaceholderFormat(Named)
+
+	s, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE name = :name AND age > :arg2", s)
+	assert.Equal(t, []interface{}{sql.Named("name", "foo"), sql.Named("arg2", 18)}, args)
+
+	s, named, err := ToSqlNamed(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE name = :name AND age > :arg2", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("name", "foo"), sql.Named("arg2", 18)}, named)
+
+	s, named, err = ToSqlNamed(Update("users").Set("age", 18).Where("id = ?", 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET age = :arg1 WHERE id = :arg2", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("arg1", 18), sql.Named("arg2", 1)}, named)
+
+	s, named, err = ToSqlNamed(Select("*").From("users").Where("name = ?", sql.Named("name", "foo")))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE name = :name", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("name", "foo")}, named)
+
+	s, named, err = ToSqlNamed(Update("users").Set("age", 18).PlaceholderFormat(Dollar))
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET age = :arg1", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("arg1", 18)}, named)
+}
+
+func TestToNamed(t *testing.T) {
+	s, named, err := ToNamed("x = ? AND y = ? AND z ?? 'a'", []interface{}{1, "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "x = @arg1 AND y = @arg2 AND z ? 'a'", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("arg1", 1), sql.Named("arg2", "foo")}, named)
+
+	s, named, err = ToNamed("x = ? OR y = ?", []interface{}{sql.Named("v", 1), sql.Named("v", 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "x = @v OR y = @v", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("v", 1)}, named)
+
+	_, _, err = ToNamed("x = ? AND y = ?", []interface{}{1})
+	assert.EqualError(t, err, "not enough args for placeholder 2, got 1")
+}
+
+func TestNamedArgPassThrough(t *testing.T) {
+	e := Expr("x = @n OR y = @n", sql.Named("n", 5))
+
+	s, args, err := Select("*").From("t").Where(e).PlaceholderFormat(Named).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE x = @n OR y = @n", s)
+	assert.Equal(t, []interface{}{sql.Named("n", 5)}, args)
+
+	_, _, err = Select("*").From("t").Where(e).ToSql()
+	assert.EqualError(t, err, "named arg n cannot be used with positional placeholder format")
+
+	s, args, err = Select("*").From("t").Where("z = ?", 1).Where(e).Where("w > ?", 2).PlaceholderFormat(Named).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE z = :arg1 AND x = @n OR y = @n AND w > :arg2", s)
+	assert.Equal(t, []interface{}{sql.Named("arg1", 1), sql.Named("n", 5), sql.Named("arg2", 2)}, args)
+
+	s, named, err := ToSqlNamed(Select("*").From("t").Where(e).Where("w > ?", 2))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE x = @n OR y = @n AND w > :arg1", s)
+	assert.Equal(t, []sql.NamedArg{sql.Named("n", 5), sql.Named("arg1", 2)}, named)
+}
+
+func TestNamedArgPositional(t *testing.T) {
+	for _, f := range []PlaceholderFormat{Question, Dollar, DollarDedup, Colon, AtP} {
+		_, _, err := Select("*").From("t").Where("x = ?", sql.Named("n", 5)).PlaceholderFormat(f).ToSql()
+		assert.EqualError(t, err, "named arg n cannot be used with positional placeholder format")
+	}
+}
+
+func TestDollarDedup(t *testing.T) {
+	s, args, err := Select("*").
+		From("posts").
+		Where("author_id = ?", 7).
+		Where(Or{Eq{"editor_id": 7}, Eq{"status": "draft"}}).
+		Where("(data = ? OR data = ?)", []byte("x"), []byte("x")).
+		PlaceholderFormat(DollarDedup).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM posts WHERE author_id = $1 AND (editor_id = $1 OR status = $2) AND (data = $3 OR data = $4)", s)
+	assert.Equal(t, []interface{}{7, "draft", []byte("x"), []byte("x")}, args)
+
+	s, args, err = DollarDedup.ReplacePlaceholdersArgs("a = ? AND b = ?", []interface{}{1, int64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "a = $1 AND b = $2", s)
+	assert.Equal(t, []interface{}{1, int64(1)}, args)
+
+	_, _, err = DollarDedup.ReplacePlaceholdersArgs("a = ?", []interface{}{1, 1})
+	assert.EqualError(t, err, "got 2 args for 1 placeholders")
+}
+
+func TestInline(t *testing.T) {
+	s, args, err := Inline.ReplacePlaceholdersArgs(
+		"CREATE INDEX active_idx ON users (email) WHERE status = ? AND level > ? AND note <> ??",
+		[]interface{}{"it's active", 3},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE INDEX active_idx ON users (email) WHERE status = 'it''s active' AND level > 3 AND note <> ?", s)
+	assert.Nil(t, args)
+
+	_, _, err = Inline.ReplacePlaceholdersArgs("a = ? AND b = ?", []interface{}{1})
+	assert.EqualError(t, err, "not enough args for placeholder 2, got 1")
+
+	_, _, err = Inline.ReplacePlaceholdersArgs("a = ?", []interface{}{1, 2})
+	assert.EqualError(t, err, "got 2 args for 1 placeholders")
+
+	_, err = Inline.ReplacePlaceholders("a = ?")
+	assert.EqualError(t, err, "not enough args for placeholder 1, got 0")
+}
+
+func TestInlineValues(t *testing.T) {
+	x := 5
+	var none *int
+	var nullStr *sql.NullString
+	s, _, err := Inline.ReplacePlaceholdersArgs("a = ? AND b IS ? AND c IS ?", []interface{}{&x, none, nullStr})
+	assert.NoError(t, err)
+	assert.Equal(t, "a = 5 AND b IS NULL AND c IS NULL", s)
+
+	_, _, err = Inline.ReplacePlaceholdersArgs("a IN (?)", []interface{}{[]int{1, 2}})
+	assert.EqualError(t, err, "cannot format []int as literal: unsupported type []int, a slice of int")
+
+	_, _, err = Inline.ReplacePlaceholdersArgs("a = ?", []interface{}{struct{}{}})
+	assert.Error(t, err)
+}
+
+func TestInlineBuilder(t *testing.T) {
+	s, args, err := Select("*").
+		From("users").
+		Where(Eq{"status": "active", "deleted": false}).
+		Where("level > ?", 3).
+		PlaceholderFormat(Inline).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE deleted = FALSE AND status = 'active' AND level > 3", s)
+	assert.Empty(t, args)
+}
+
 func TestPlaceholders(t *testing.T) {
 	assert.Equal(t, Placeholders(2), "?,?")
 }
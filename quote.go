@@ -0,0 +1,63 @@
+package sqrl
+
+import "strings"
+
+// Quote describes how identifiers (e.g. table and column names) are quoted.
+type Quote struct {
+	open  string
+	close string
+}
+
+var (
+	// QuoteDouble quotes identifiers with double quotes as defined by ANSI SQL,
+	// used by PostgreSQL, SQLite and Oracle.
+	QuoteDouble = Quote{`"`, `"`}
+
+	// QuoteBacktick quotes identifiers with backticks, used by MySQL.
+	QuoteBacktick = Quote{"`", "`"}
+
+	// QuoteBracket quotes identifiers with square brackets, used by SQL Server.
+	QuoteBracket = Quote{"[", "]"}
+)
+
+// Ident quotes identifier name. Parts of qualified names separated by dots
+// are quoted separately, "*" is left as is. Closing quote characters inside
+// of name are escaped by doubling them.
+//
+// Ex:
+//     QuoteDouble.Ident("public.users") // "public"."users"
+func (q Quote) Ident(name string) string {
+	if q == (Quote{}) {
+		q = QuoteDouble
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" {
+			continue
+		}
+		parts[i] = q.open + strings.Replace(part, q.close, q.close+q.close, -1) + q.close
+	}
+	return strings.Join(parts, ".")
+}
+
+// identExpr is a quoted identifier
+type identExpr struct {
+	name  string
+	quote Quote
+}
+
+func (e identExpr) ToSql() (sql string, args []interface{}, err error) {
+	return e.quote.Ident(e.name), nil, nil
+}
+
+// Ident returns quoted identifier name for use as a column or in expressions,
+// quoted with double quotes unless StatementBuilder is set up otherwise.
+//
+// See StatementBuilderType.Ident.
+//
+// Ex:
+//     Select().Column(Ident("select")).From("t") // SELECT "select" FROM t
+func Ident(name string) identExpr {
+	return StatementBuilder.Ident(name)
+}
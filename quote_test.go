@@ -0,0 +1,38 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	assert.Equal(t, `"select"`, QuoteDouble.Ident("select"))
+	assert.Equal(t, `"public"."users"`, QuoteDouble.Ident("public.users"))
+	assert.Equal(t, `"u".*`, QuoteDouble.Ident("u.*"))
+	assert.Equal(t, `"a""b"`, QuoteDouble.Ident(`a"b`))
+	assert.Equal(t, "`order`", QuoteBacktick.Ident("order"))
+	assert.Equal(t, "`a``b`", QuoteBacktick.Ident("a`b"))
+	assert.Equal(t, "[dbo].[user]", QuoteBracket.Ident("dbo.user"))
+	assert.Equal(t, "[a]]b]", QuoteBracket.Ident("a]b"))
+}
+
+func TestIdent(t *testing.T) {
+	sql, args, err := Select().
+		Column(Ident("select")).
+		From(QuoteDouble.Ident("order")).
+		Where(Eq{QuoteDouble.Ident("from"): 1}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "select" FROM "order" WHERE "from" = $1`, sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestStatementBuilderQuoteWith(t *testing.T) {
+	sb := StatementBuilder.QuoteWith(QuoteBacktick)
+
+	sql, _, err := sb.Select().Column(sb.Ident("select")).From("t").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `select` FROM t", sql)
+}
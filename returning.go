@@ -16,6 +16,10 @@ func (r *returning) ReturningSelect(from *SelectBuilder, alias string) {
 	*r = append(*r, Alias(from, alias))
 }
 
+func (r *returning) ReturningExpr(expr interface{}, args ...interface{}) {
+	*r = append(*r, newPart(expr, args...))
+}
+
 func (r *returning) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
 	io.WriteString(w, " RETURNING ")
 	return appendToSql(*r, w, ", ", args)
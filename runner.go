@@ -0,0 +1,430 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WithTimeout returns Runner wrapping runner, which bounds statements run with
+// Context variants of its methods by timeout. The statement context is derived
+// from the one given by caller, so that shorter deadlines of callers are kept.
+// Exec, Query and QueryRow are passed to runner unchanged, but builders run
+// statements with the Context variants, so they are bounded as well.
+//
+// Contexts of QueryContext and QueryRowContext are released once rows are
+// closed or the row is scanned.
+//
+// Ex:
+//     Select("*").From("users").RunWith(WithTimeout(db, 5*time.Second))
+func WithTimeout(runner BaseRunner, timeout time.Duration) Runner {
+	return &timeoutRunner{Runner: wrapRunner(runner), timeout: timeout}
+}
+
+// timeoutRunner is a Runner bounding statements by timeout
+type timeoutRunner struct {
+	Runner
+	timeout time.Duration
+}
+
+func (r *timeoutRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.Runner.ExecContext(ctx, query, args...)
+}
+
+func (r *timeoutRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	rows, err := r.Runner.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelRows{RowsScanner: rows, cancel: cancel}, nil
+}
+
+func (r *timeoutRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	return &cancelRow{RowScanner: r.Runner.QueryRowContext(ctx, query, args...), cancel: cancel}
+}
+
+// cancelRows releases context of the query once rows are closed
+type cancelRows struct {
+	RowsScanner
+	cancel context.CancelFunc
+}
+
+func (r *cancelRows) Close() error {
+	defer r.cancel()
+	return r.RowsScanner.Close()
+}
+
+// cancelRow releases context of the query once the row is scanned
+type cancelRow struct {
+	RowScanner
+	cancel context.CancelFunc
+}
+
+func (r *cancelRow) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	return r.RowScanner.Scan(dest...)
+}
+
+// RetryPolicy configures which statements are retried by WithRetry and how.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first failed attempt.
+	MaxRetries int
+	// Backoff returns time to wait before given retry, starting from 1.
+	// Retries are not delayed if nil, see ExponentialBackoff.
+	Backoff func(retry int) time.Duration
+	// Retriable reports whether statement that failed with err can be retried.
+	// Defaults to IsSerializationFailure if nil.
+	Retriable func(err error) bool
+	// Exec enables retries of Exec statements.
+	Exec bool
+	// Query enables retries of Query and QueryRow statements.
+	Query bool
+}
+
+// ExponentialBackoff returns RetryPolicy.Backoff function doubling delay
+// with every retry, starting from base.
+func ExponentialBackoff(base time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		return base << uint(retry-1)
+	}
+}
+
+// IsSerializationFailure reports whether err is a serialization failure
+// (SQLSTATE 40001) or a deadlock (SQLSTATE 40P01), after which the statement
+// can be retried. Errors are recognized by SQLState() string method
+// implemented by errors of PostgreSQL drivers like pgx and lib/pq.
+func IsSerializationFailure(err error) bool {
+	var state interface {
+		SQLState() string
+	}
+	if !errors.As(err, &state) {
+		return false
+	}
+	switch state.SQLState() {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
+
+// WithRetry returns Runner wrapping runner, which retries statements failed
+// with retriable errors according to policy.
+//
+// Retries happen on statement level, so only idempotent statements running
+// outside of transactions should be retried. Errors of Query are checked once
+// it returns, errors of QueryRow once the row is scanned. If retries of queries
+// are enabled, QueryRow runs the query only when the row is scanned.
+//
+// Ex:
+//     db := WithRetry(db, RetryPolicy{MaxRetries: 3, Backoff: ExponentialBackoff(10 * time.Millisecond), Query: true})
+func WithRetry(runner BaseRunner, policy RetryPolicy) Runner {
+	if policy.Retriable == nil {
+		policy.Retriable = IsSerializationFailure
+	}
+	return &retryRunner{Runner: wrapRunner(runner), policy: policy}
+}
+
+// retryRunner is a Runner retrying failed statements
+type retryRunner struct {
+	Runner
+	policy RetryPolicy
+}
+
+// retry calls run until it succeeds, fails with an error that cannot be retried
+// or retries are exhausted
+func (r *retryRunner) retry(ctx context.Context, enabled bool, run func() error) error {
+	for retry := 1; ; retry++ {
+		err := run()
+		if err == nil || !enabled || retry > r.policy.MaxRetries || !r.policy.Retriable(err) {
+			return err
+		}
+
+		if r.policy.Backoff != nil {
+			timer := time.NewTimer(r.policy.Backoff(retry))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (r *retryRunner) Exec(query string, args ...interface{}) (res sql.Result, err error) {
+	err = r.retry(context.Background(), r.policy.Exec, func() (err error) {
+		res, err = r.Runner.Exec(query, args...)
+		return
+	})
+	return
+}
+
+func (r *retryRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	err = r.retry(ctx, r.policy.Exec, func() (err error) {
+		res, err = r.Runner.ExecContext(ctx, query, args...)
+		return
+	})
+	return
+}
+
+func (r *retryRunner) Query(query string, args ...interface{}) (rows RowsScanner, err error) {
+	err = r.retry(context.Background(), r.policy.Query, func() (err error) {
+		rows, err = r.Runner.Query(query, args...)
+		return
+	})
+	return
+}
+
+func (r *retryRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (rows RowsScanner, err error) {
+	err = r.retry(ctx, r.policy.Query, func() (err error) {
+		rows, err = r.Runner.QueryContext(ctx, query, args...)
+		return
+	})
+	return
+}
+
+func (r *retryRunner) QueryRow(query string, args ...interface{}) RowScanner {
+	if !r.policy.Query {
+		return r.Runner.QueryRow(query, args...)
+	}
+	return &retryRow{runner: r, ctx: context.Background(), query: func() RowScanner {
+		return r.Runner.QueryRow(query, args...)
+	}}
+}
+
+func (r *retryRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	if !r.policy.Query {
+		return r.Runner.QueryRowContext(ctx, query, args...)
+	}
+	return &retryRow{runner: r, ctx: ctx, query: func() RowScanner {
+		return r.Runner.QueryRowContext(ctx, query, args...)
+	}}
+}
+
+// retryRow runs the query when scanned, so that failed scans can be retried.
+// It is used only if retries of queries are enabled.
+type retryRow struct {
+	runner *retryRunner
+	ctx    context.Context
+	query  func() RowScanner
+}
+
+func (r *retryRow) Scan(dest ...interface{}) error {
+	return r.runner.retry(r.ctx, r.runner.policy.Query, func() error {
+		return r.query().Scan(dest...)
+	})
+}
+
+// QueryLog describes a statement run by Runner returned from WithLogging.
+type QueryLog struct {
+	// Query is the SQL of the statement.
+	Query string
+	// ArgCount is the number of args bound to the statement.
+	ArgCount int
+	// Args are values bound to the statement, set only if enabled by LogArgs.
+	Args []interface{}
+	// Duration is time it took to run the statement.
+	Duration time.Duration
+	// Slow is set if Duration reached the slow query threshold.
+	Slow bool
+	// Err is the error returned by the statement, if any.
+	Err error
+}
+
+// LoggingOption configures Runner created by WithLogging.
+type LoggingOption func(*loggingRunner)
+
+// LogArgs enables logging of values of args bound to statements. Args are
+// not logged by default, as they might contain personal data.
+func LogArgs() LoggingOption {
+	return func(r *loggingRunner) {
+		r.logArgs = true
+	}
+}
+
+// WithLogging returns Runner wrapping runner, which calls logf after every
+// statement. Statements that took at least slowThreshold are flagged as slow,
+// slowThreshold <= 0 disables the flag.
+//
+// Duration of Query is measured until rows are returned, duration of QueryRow
+// until the row is scanned.
+//
+// Ex:
+//     db := WithLogging(db, func(l QueryLog) {
+//         log.Printf("%s (%d args) took %s, slow: %t, error: %v", l.Query, l.ArgCount, l.Duration, l.Slow, l.Err)
+//     }, time.Second)
+func WithLogging(runner BaseRunner, logf func(QueryLog), slowThreshold time.Duration, opts ...LoggingOption) Runner {
+	r := &loggingRunner{Runner: wrapRunner(runner), logf: logf, slowThreshold: slowThreshold}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// loggingRunner is a Runner logging every statement
+type loggingRunner struct {
+	Runner
+	logf          func(QueryLog)
+	slowThreshold time.Duration
+	logArgs       bool
+}
+
+func (r *loggingRunner) log(start time.Time, query string, args []interface{}, err error) {
+	l := QueryLog{
+		Query:    query,
+		ArgCount: len(args),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if r.logArgs {
+		l.Args = args
+	}
+	l.Slow = r.slowThreshold > 0 && l.Duration >= r.slowThreshold
+	r.logf(l)
+}
+
+func (r *loggingRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := r.Runner.Exec(query, args...)
+	r.log(start, query, args, err)
+	return res, err
+}
+
+func (r *loggingRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := r.Runner.ExecContext(ctx, query, args...)
+	r.log(start, query, args, err)
+	return res, err
+}
+
+func (r *loggingRunner) Query(query string, args ...interface{}) (RowsScanner, error) {
+	start := time.Now()
+	rows, err := r.Runner.Query(query, args...)
+	r.log(start, query, args, err)
+	return rows, err
+}
+
+func (r *loggingRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	start := time.Now()
+	rows, err := r.Runner.QueryContext(ctx, query, args...)
+	r.log(start, query, args, err)
+	return rows, err
+}
+
+func (r *loggingRunner) QueryRow(query string, args ...interface{}) RowScanner {
+	start := time.Now()
+	return &loggingRow{RowScanner: r.Runner.QueryRow(query, args...), runner: r, start: start, query: query, args: args}
+}
+
+func (r *loggingRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	start := time.Now()
+	return &loggingRow{RowScanner: r.Runner.QueryRowContext(ctx, query, args...), runner: r, start: start, query: query, args: args}
+}
+
+// loggingRow logs the query once the row is scanned
+type loggingRow struct {
+	RowScanner
+	runner *loggingRunner
+	start  time.Time
+	query  string
+	args   []interface{}
+}
+
+func (r *loggingRow) Scan(dest ...interface{}) error {
+	err := r.RowScanner.Scan(dest...)
+	r.runner.log(r.start, r.query, r.args, err)
+	return err
+}
+
+// RecordedCall describes a statement received by RecordingRunner.
+type RecordedCall struct {
+	// Method is the name of the Runner method called, e.g. "ExecContext".
+	Method string
+	// SQL is the statement.
+	SQL string
+	// Args are the args bound to the statement.
+	Args []interface{}
+}
+
+// RecordingRunner is a Runner recording every statement it receives before
+// passing it to the wrapped runner, which is useful for assertions in tests.
+// It is safe for concurrent use.
+//
+// Ex:
+//     r := NewRecordingRunner(db)
+//     Update("users").Set("name", "foo").Where("id = ?", 1).RunWith(r).Exec()
+//     r.Calls() // [{ExecContext UPDATE users SET name = ? WHERE id = ? [foo 1]}]
+type RecordingRunner struct {
+	runner Runner
+
+	mu    sync.Mutex
+	calls []RecordedCall
+}
+
+// NewRecordingRunner returns RecordingRunner wrapping runner.
+func NewRecordingRunner(runner BaseRunner) *RecordingRunner {
+	return &RecordingRunner{runner: wrapRunner(runner)}
+}
+
+// Calls returns statements received so far in order.
+func (r *RecordingRunner) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// Reset forgets statements received so far.
+func (r *RecordingRunner) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *RecordingRunner) record(method, query string, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, RecordedCall{Method: method, SQL: query, Args: append([]interface{}(nil), args...)})
+}
+
+// Exec records the statement and runs Exec of the wrapped runner.
+func (r *RecordingRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	r.record("Exec", query, args)
+	return r.runner.Exec(query, args...)
+}
+
+// ExecContext records the statement and runs ExecContext of the wrapped runner.
+func (r *RecordingRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.record("ExecContext", query, args)
+	return r.runner.ExecContext(ctx, query, args...)
+}
+
+// Query records the statement and runs Query of the wrapped runner.
+func (r *RecordingRunner) Query(query string, args ...interface{}) (RowsScanner, error) {
+	r.record("Query", query, args)
+	return r.runner.Query(query, args...)
+}
+
+// QueryContext records the statement and runs QueryContext of the wrapped runner.
+func (r *RecordingRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	r.record("QueryContext", query, args)
+	return r.runner.QueryContext(ctx, query, args...)
+}
+
+// QueryRow records the statement and runs QueryRow of the wrapped runner.
+func (r *RecordingRunner) QueryRow(query string, args ...interface{}) RowScanner {
+	r.record("QueryRow", query, args)
+	return r.runner.QueryRow(query, args...)
+}
+
+// QueryRowContext records the statement and runs QueryRowContext of the wrapped runner.
+func (r *RecordingRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	r.record("QueryRowContext", query, args)
+	return r.runner.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,303 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// slowRunner takes delay to run each statement unless context is done first
+type slowRunner struct {
+	delay time.Duration
+}
+
+func (r *slowRunner) wait(ctx context.Context) error {
+	select {
+	case <-time.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *slowRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.ExecContext(context.Background(), query, args...)
+}
+
+func (r *slowRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, r.wait(ctx)
+}
+
+func (r *slowRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &ctxRows{ctx: ctx}, nil
+}
+
+func (r *slowRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	return &Row{err: r.wait(ctx)}
+}
+
+// ctxRows is an empty result reporting error of its query context
+type ctxRows struct {
+	ctx context.Context
+}
+
+func (r *ctxRows) Columns() ([]string, error) { return nil, nil }
+func (r *ctxRows) Next() bool                 { return false }
+func (r *ctxRows) Close() error               { return nil }
+func (r *ctxRows) Err() error                 { return r.ctx.Err() }
+func (r *ctxRows) Scan(...interface{}) error  { return nil }
+
+func TestWithTimeout(t *testing.T) {
+	db := WithTimeout(&slowRunner{delay: time.Second}, 10*time.Millisecond)
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	_, err = Select("*").From("t").RunWith(db).Query()
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	err = Select("*").From("t").RunWith(db).QueryRow().Scan()
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWithTimeoutFast(t *testing.T) {
+	db := WithTimeout(&slowRunner{delay: time.Millisecond}, time.Second)
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.NoError(t, err)
+
+	rows, err := Select("*").From("t").RunWith(db).Query()
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Err())
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, context.Canceled, rows.(*cancelRows).RowsScanner.Err())
+}
+
+func TestWithTimeoutWithoutContext(t *testing.T) {
+	db := WithTimeout(&slowRunner{delay: 20 * time.Millisecond}, time.Millisecond)
+
+	_, err := db.Exec("UPDATE t SET a = 1")
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), "UPDATE t SET a = 1")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWithTimeoutCallerDeadline(t *testing.T) {
+	db := WithTimeout(&slowRunner{delay: time.Second}, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := Update("t").Set("a", 1).RunWith(db).ExecContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+// sqlStateError is a driver error with SQLSTATE code
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+// flakyRunner fails first failures statements with err
+type flakyRunner struct {
+	DBStub
+	failures int
+	err      error
+	calls    int
+}
+
+func (r *flakyRunner) fail() error {
+	r.calls++
+	if r.calls <= r.failures {
+		return r.err
+	}
+	return nil
+}
+
+func (r *flakyRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := r.fail(); err != nil {
+		return nil, err
+	}
+	return r.DBStub.ExecContext(ctx, query, args...)
+}
+
+func (r *flakyRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	if err := r.fail(); err != nil {
+		return nil, err
+	}
+	return &ctxRows{ctx: ctx}, nil
+}
+
+func (r *flakyRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	return &Row{RowScanner: &ctxRows{ctx: ctx}, err: r.fail()}
+}
+
+func TestWithRetry(t *testing.T) {
+	stub := &flakyRunner{failures: 2, err: sqlStateError("40001")}
+	db := WithRetry(stub, RetryPolicy{MaxRetries: 3, Backoff: ExponentialBackoff(time.Millisecond), Exec: true, Query: true})
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stub.calls)
+	assert.Equal(t, "UPDATE t SET a = ?", stub.LastExecSql)
+
+	stub.calls = 0
+	_, err = Select("*").From("t").RunWith(db).Query()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stub.calls)
+
+	stub.calls = 0
+	err = Select("*").From("t").RunWith(db).QueryRow().Scan()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stub.calls)
+}
+
+func TestWithRetryGiveUp(t *testing.T) {
+	stub := &flakyRunner{failures: 5, err: sqlStateError("40P01")}
+	db := WithRetry(stub, RetryPolicy{MaxRetries: 2, Exec: true})
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.Equal(t, sqlStateError("40P01"), err)
+	assert.Equal(t, 3, stub.calls)
+
+	stub.calls = 0
+	_, err = Select("*").From("t").RunWith(db).Query()
+	assert.Equal(t, sqlStateError("40P01"), err)
+	assert.Equal(t, 1, stub.calls, "queries are not retried unless enabled")
+
+	stub.calls = 0
+	row := Select("*").From("t").RunWith(db).QueryRow()
+	assert.Equal(t, 1, stub.calls, "row is queried right away unless retries are enabled")
+	assert.Equal(t, sqlStateError("40P01"), row.Scan())
+	assert.Equal(t, 1, stub.calls)
+
+	stub = &flakyRunner{failures: 5, err: sqlStateError("23505")}
+	db = WithRetry(stub, RetryPolicy{MaxRetries: 2, Exec: true})
+
+	_, err = Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.Equal(t, sqlStateError("23505"), err)
+	assert.Equal(t, 1, stub.calls, "unique violation is not retriable")
+}
+
+func TestWithRetryCustomClassifier(t *testing.T) {
+	errBusy := errors.New("database is locked")
+	stub := &flakyRunner{failures: 1, err: errBusy}
+	db := WithRetry(stub, RetryPolicy{
+		MaxRetries: 1,
+		Retriable:  func(err error) bool { return err == errBusy },
+		Exec:       true,
+	})
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.calls)
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	assert.True(t, IsSerializationFailure(sqlStateError("40001")))
+	assert.True(t, IsSerializationFailure(fmt.Errorf("wrapped: %w", sqlStateError("40P01"))))
+	assert.False(t, IsSerializationFailure(sqlStateError("23505")))
+	assert.False(t, IsSerializationFailure(errors.New("40001")))
+	assert.False(t, IsSerializationFailure(nil))
+}
+
+func TestWithLogging(t *testing.T) {
+	var logs []QueryLog
+	db := WithLogging(&slowRunner{delay: 5 * time.Millisecond}, func(l QueryLog) {
+		logs = append(logs, l)
+	}, time.Hour)
+
+	_, err := Update("t").Set("a", 1).Where("id = ?", 2).RunWith(db).Exec()
+	assert.NoError(t, err)
+
+	rows, err := Select("*").From("t").RunWith(db).Query()
+	assert.NoError(t, err)
+	rows.Close()
+
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "UPDATE t SET a = ? WHERE id = ?", logs[0].Query)
+	assert.Equal(t, 2, logs[0].ArgCount)
+	assert.Nil(t, logs[0].Args)
+	assert.True(t, logs[0].Duration > 0)
+	assert.False(t, logs[0].Slow)
+	assert.NoError(t, logs[0].Err)
+	assert.Equal(t, "SELECT * FROM t", logs[1].Query)
+	assert.True(t, logs[1].Duration > 0)
+}
+
+func TestWithLoggingSlowAndArgs(t *testing.T) {
+	var logs []QueryLog
+	stub := &DBStub{err: errors.New("boom")}
+	db := WithLogging(stub, func(l QueryLog) {
+		logs = append(logs, l)
+	}, time.Nanosecond, LogArgs())
+
+	_, err := Update("t").Set("a", 1).RunWith(db).Exec()
+	assert.EqualError(t, err, "boom")
+
+	err = Select("*").From("t").Where("id = ?", 2).RunWith(db).QueryRow().Scan()
+	assert.NoError(t, err)
+
+	assert.Len(t, logs, 2)
+	assert.Equal(t, []interface{}{1}, logs[0].Args)
+	assert.True(t, logs[0].Slow)
+	assert.EqualError(t, logs[0].Err, "boom")
+	assert.Equal(t, "SELECT * FROM t WHERE id = ?", logs[1].Query)
+	assert.Equal(t, []interface{}{2}, logs[1].Args)
+}
+
+func TestRecordingRunner(t *testing.T) {
+	db := &DBStub{}
+	r := NewRecordingRunner(db)
+
+	_, err := Update("users").Set("name", "foo").Where("id = ?", 1).RunWith(r).Exec()
+	assert.NoError(t, err)
+	err = Select("COUNT(*)").From("users").Where("age > ?", 18).PlaceholderFormat(Dollar).RunWith(r).Scan()
+	assert.NoError(t, err)
+	_, err = r.Query("SELECT 1")
+	assert.NoError(t, err)
+
+	expected := []RecordedCall{
+		{Method: "ExecContext", SQL: "UPDATE users SET name = ? WHERE id = ?", Args: []interface{}{"foo", 1}},
+		{Method: "QueryRowContext", SQL: "SELECT COUNT(*) FROM users WHERE age > $1", Args: []interface{}{18}},
+		{Method: "Query", SQL: "SELECT 1", Args: nil},
+	}
+	assert.Equal(t, expected, r.Calls())
+	assert.Equal(t, "SELECT 1", db.LastQuerySql)
+
+	r.Reset()
+	assert.Empty(t, r.Calls())
+}
+
+func TestRecordingRunnerConcurrent(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+	r := NewRecordingRunner(db)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := ExecWith(r, Delete("t").Where("id = ?", i))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	calls := r.Calls()
+	assert.Len(t, calls, 10)
+	for _, call := range calls {
+		assert.Equal(t, "DELETE FROM t WHERE id = ?", call.SQL)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -13,23 +14,51 @@ import (
 type SelectBuilder struct {
 	StatementBuilderType
 
-	prefixes    exprs
+	prefixes    []Sqlizer
+	ctes        []cte
+	recursive   bool
 	distinct    bool
+	distinctOn  []string
 	options     []string
 	columns     []Sqlizer
 	fromParts   []Sqlizer
 	joins       []Sqlizer
+	dedupJoins  bool
 	whereParts  []Sqlizer
 	groupBys    []string
 	havingParts []Sqlizer
-	orderBys    []string
+	windows     []Sqlizer
+	setOps      []setOp
+	orderBys    []Sqlizer
 
 	limit       uint64
 	limitValid  bool
+	limitAll    bool
 	offset      uint64
 	offsetValid bool
 
-	suffixes exprs
+	lockStrength string
+	lockTables   []string
+	lockWait     string
+
+	suffixes   []Sqlizer
+	suffixArgs []interface{}
+
+	loadLenient bool
+	pageColumns []string
+}
+
+// cte is a common table expression of the WITH clause.
+type cte struct {
+	name    string
+	columns []string
+	query   Sqlizer
+}
+
+// setOp is a set operation (e.g. UNION) combining the query with another one.
+type setOp struct {
+	operator string
+	query    *SelectBuilder
 }
 
 // NewSelectBuilder creates new instance of SelectBuilder
@@ -37,15 +66,49 @@ func NewSelectBuilder(b StatementBuilderType) *SelectBuilder {
 	return &SelectBuilder{StatementBuilderType: b}
 }
 
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+//
+// Clauses are copied, but their args and nested builders (e.g. subqueries)
+// are shared between the original and the copy.
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	c := *b
+	c.prefixes = append([]Sqlizer(nil), b.prefixes...)
+	c.ctes = append([]cte(nil), b.ctes...)
+	c.distinctOn = append([]string(nil), b.distinctOn...)
+	c.options = append([]string(nil), b.options...)
+	c.columns = append([]Sqlizer(nil), b.columns...)
+	c.fromParts = append([]Sqlizer(nil), b.fromParts...)
+	c.joins = append([]Sqlizer(nil), b.joins...)
+	c.whereParts = append([]Sqlizer(nil), b.whereParts...)
+	c.groupBys = append([]string(nil), b.groupBys...)
+	c.havingParts = append([]Sqlizer(nil), b.havingParts...)
+	c.windows = append([]Sqlizer(nil), b.windows...)
+	c.setOps = append([]setOp(nil), b.setOps...)
+	c.orderBys = append([]Sqlizer(nil), b.orderBys...)
+	c.lockTables = append([]string(nil), b.lockTables...)
+	c.suffixes = append([]Sqlizer(nil), b.suffixes...)
+	c.suffixArgs = append([]interface{}(nil), b.suffixArgs...)
+	c.pageColumns = append([]string(nil), b.pageColumns...)
+	return &c
+}
+
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b *SelectBuilder) RunWith(runner BaseRunner) *SelectBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
 
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *SelectBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *SelectBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b *SelectBuilder) Exec() (sql.Result, error) {
-	return b.ExecContext(context.Background())
+	return b.ExecContext(b.runContext())
 }
 
 // ExecContext builds and Execs the query with the Runner set by RunWith using given context.
@@ -58,7 +121,7 @@ func (b *SelectBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *SelectBuilder) Query() (RowsScanner, error) {
-	return b.QueryContext(context.Background())
+	return b.QueryContext(b.runContext())
 }
 
 // QueryContext builds and Querys the query with the Runner set by RunWith in given context.
@@ -71,7 +134,7 @@ func (b *SelectBuilder) QueryContext(ctx context.Context) (RowsScanner, error) {
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b *SelectBuilder) QueryRow() RowScanner {
-	return b.QueryRowContext(context.Background())
+	return b.QueryRowContext(b.runContext())
 }
 
 func (b *SelectBuilder) QueryRowContext(ctx context.Context) RowScanner {
@@ -99,24 +162,107 @@ func (b *SelectBuilder) PlaceholderFormat(f PlaceholderFormat) *SelectBuilder {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b *SelectBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *SelectBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
 	if len(b.columns) == 0 {
 		err = fmt.Errorf("select statements must have at least one result column")
 		return
 	}
+	if len(b.lockStrength) == 0 && (len(b.lockTables) > 0 || len(b.lockWait) > 0) {
+		err = fmt.Errorf("select statements must specify ForUpdate or ForShare to use locking options")
+		return
+	}
+	if b.distinct && len(b.distinctOn) > 0 {
+		err = fmt.Errorf("select statements cannot have both DISTINCT and DISTINCT ON")
+		return
+	}
+	if len(b.distinctOn) > 0 {
+		if err = b.checkPostgresClause("DISTINCT ON"); err != nil {
+			return
+		}
+	}
+	if b.fetchSyntax && (b.limitValid || b.offsetValid) && len(b.orderBys) == 0 {
+		err = fmt.Errorf("select statements must have ORDER BY clause to use OFFSET ... FETCH syntax")
+		return
+	}
 
 	sql := &bytes.Buffer{}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.prefixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
+	if len(b.ctes) > 0 {
+		sql.WriteString("WITH ")
+		if b.recursive {
+			sql.WriteString("RECURSIVE ")
+		}
+
+		names := make(map[string]bool, len(b.ctes))
+		for i, c := range b.ctes {
+			name := c.name
+			if p := strings.Index(name, "("); p >= 0 {
+				name = strings.TrimSpace(name[:p])
+			}
+			if names[name] {
+				err = fmt.Errorf("duplicate common table expression %s", name)
+				return
+			}
+			names[name] = true
+
+			var cteSql string
+			var cteArgs []interface{}
+			cteSql, cteArgs, err = nestedToSql(c.query)
+			if err != nil {
+				return
+			}
+
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString(c.name)
+			if len(c.columns) > 0 {
+				sql.WriteString("(")
+				sql.WriteString(strings.Join(c.columns, ", "))
+				sql.WriteString(")")
+			}
+			sql.WriteString(" AS (")
+			sql.WriteString(cteSql)
+			sql.WriteString(")")
+			args = append(args, cteArgs...)
+		}
+		sql.WriteString(" ")
+	}
+
+	if len(b.setOps) > 0 {
+		sql.WriteString("(")
+	}
+
 	sql.WriteString("SELECT ")
 
 	if b.distinct {
 		sql.WriteString("DISTINCT ")
 	}
 
+	if len(b.distinctOn) > 0 {
+		sql.WriteString("DISTINCT ON (")
+		sql.WriteString(strings.Join(b.distinctOn, ", "))
+		sql.WriteString(") ")
+	}
+
 	if len(b.options) > 0 {
 		sql.WriteString(strings.Join(b.options, " "))
 		sql.WriteString(" ")
@@ -138,16 +284,23 @@ func (b *SelectBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.joins) > 0 {
+		joins := b.joins
+		if b.dedupJoins {
+			joins, err = uniqueJoins(joins)
+			if err != nil {
+				return
+			}
+		}
+
 		sql.WriteString(" ")
-		args, err = appendToSql(b.joins, sql, " ", args)
+		args, err = appendToSql(joins, sql, " ", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if len(b.whereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(b.whereParts, sql, " AND ", args)
+		args, err = appendClauseToSql(b.whereParts, sql, " WHERE ", " AND ", args)
 		if err != nil {
 			return
 		}
@@ -159,37 +312,101 @@ func (b *SelectBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.havingParts) > 0 {
-		sql.WriteString(" HAVING ")
-		args, err = appendToSql(b.havingParts, sql, " AND ", args)
+		args, err = appendClauseToSql(b.havingParts, sql, " HAVING ", " AND ", args)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(b.windows) > 0 {
+		sql.WriteString(" WINDOW ")
+		args, err = appendToSql(b.windows, sql, ", ", args)
 		if err != nil {
 			return
 		}
 	}
 
+	if len(b.setOps) > 0 {
+		sql.WriteString(")")
+		for _, op := range b.setOps {
+			if op.query == nil {
+				err = fmt.Errorf("%s operand must not be nil", op.operator)
+				return
+			}
+
+			var opSql string
+			var opArgs []interface{}
+			opSql, opArgs, err = op.query.toSqlRaw()
+			if err != nil {
+				return
+			}
+
+			sql.WriteString(" ")
+			sql.WriteString(op.operator)
+			sql.WriteString(" (")
+			sql.WriteString(opSql)
+			sql.WriteString(")")
+			args = append(args, opArgs...)
+		}
+	}
+
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(b.orderBys, ", "))
+		args, err = appendToSql(b.orderBys, sql, ", ", args)
+		if err != nil {
+			return
+		}
 	}
 
-	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
-	if b.limitValid {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(strconv.FormatUint(b.limit, 10))
+	if b.fetchSyntax {
+		if b.limitValid || b.offsetValid {
+			sql.WriteString(" OFFSET ? ROWS")
+			args = append(args, b.offset)
+		}
+		if b.limitValid && !b.limitAll {
+			sql.WriteString(" FETCH NEXT ? ROWS ONLY")
+			args = append(args, b.limit)
+		}
+	} else {
+		if b.limitValid {
+			sql.WriteString(" LIMIT ")
+			if b.limitAll {
+				sql.WriteString("ALL")
+			} else {
+				sql.WriteString(strconv.FormatUint(b.limit, 10))
+			}
+		}
+
+		if b.offsetValid {
+			sql.WriteString(" OFFSET ")
+			sql.WriteString(strconv.FormatUint(b.offset, 10))
+		}
 	}
 
-	if b.offsetValid {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(strconv.FormatUint(b.offset, 10))
+	if len(b.lockStrength) > 0 {
+		sql.WriteString(" FOR ")
+		sql.WriteString(b.lockStrength)
+		if len(b.lockTables) > 0 {
+			sql.WriteString(" OF ")
+			sql.WriteString(strings.Join(b.lockTables, ", "))
+		}
+		if len(b.lockWait) > 0 {
+			sql.WriteString(" ")
+			sql.WriteString(b.lockWait)
+		}
 	}
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.suffixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
+	args = append(args, b.suffixArgs...)
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	sqlStr = sql.String()
 	return
-
 }
 
 // Prefix adds an expression to the beginning of the query
@@ -198,6 +415,42 @@ func (b *SelectBuilder) Prefix(sql string, args ...interface{}) *SelectBuilder {
 	return b
 }
 
+// PrefixExpr adds an expression built by expr to the beginning of the query.
+// Its args are placed before args of the query.
+func (b *SelectBuilder) PrefixExpr(expr Sqlizer) *SelectBuilder {
+	b.prefixes = append(b.prefixes, newPart(expr))
+	return b
+}
+
+// With adds a common table expression to the WITH clause of the query.
+// The expression is referred to by name in From, Join and subqueries, its args
+// are bound once before args of the query however many times it is referred to.
+// Each name can be used only once.
+//
+// Ex:
+//     Select("*").From("cte").With("cte", Select("a").From("b"))
+func (b *SelectBuilder) With(name string, query Sqlizer) *SelectBuilder {
+	b.ctes = append(b.ctes, cte{name: name, query: query})
+	return b
+}
+
+// WithColumns adds a common table expression with explicit column names,
+// e.g. "WITH name(a, b) AS (...)", to the WITH clause of the query.
+func (b *SelectBuilder) WithColumns(name string, columns []string, query Sqlizer) *SelectBuilder {
+	b.ctes = append(b.ctes, cte{name: name, columns: columns, query: query})
+	return b
+}
+
+// WithRecursive adds a common table expression to the WITH clause of the query
+// and turns the clause into WITH RECURSIVE.
+//
+// The name is passed through verbatim, so column names can be included in it,
+// e.g. WithRecursive("t(n)", query).
+func (b *SelectBuilder) WithRecursive(name string, query Sqlizer) *SelectBuilder {
+	b.recursive = true
+	return b.With(name, query)
+}
+
 // Distinct adds a DISTINCT clause to the query.
 func (b *SelectBuilder) Distinct() *SelectBuilder {
 	b.distinct = true
@@ -205,6 +458,14 @@ func (b *SelectBuilder) Distinct() *SelectBuilder {
 	return b
 }
 
+// DistinctOn adds a DISTINCT ON (...) clause to the query.
+//
+// SELECT DISTINCT ON is a PostgreSQL specific extension
+func (b *SelectBuilder) DistinctOn(columns ...string) *SelectBuilder {
+	b.distinctOn = append(b.distinctOn, columns...)
+	return b
+}
+
 // Options adds select option to the query
 func (b *SelectBuilder) Options(options ...string) *SelectBuilder {
 	for _, str := range options {
@@ -232,6 +493,16 @@ func (b *SelectBuilder) Column(column interface{}, args ...interface{}) *SelectB
 	return b
 }
 
+// ColumnIf adds a result column to the query if cond is true.
+//
+// See Column.
+func (b *SelectBuilder) ColumnIf(cond bool, column interface{}, args ...interface{}) *SelectBuilder {
+	if cond {
+		b.Column(column, args...)
+	}
+	return b
+}
+
 // From sets the FROM clause of the query.
 func (b *SelectBuilder) From(tables ...string) *SelectBuilder {
 	parts := make([]Sqlizer, len(tables))
@@ -243,12 +514,85 @@ func (b *SelectBuilder) From(tables ...string) *SelectBuilder {
 	return b
 }
 
-// FromSelect sets a subquery into the FROM clause of the query.
+// FromSelect sets a subquery into the FROM clause of the query, replacing any
+// tables set before.
+//
+// Args of the subquery are bound before args of the outer query.
 func (b *SelectBuilder) FromSelect(from *SelectBuilder, alias string) *SelectBuilder {
-	b.fromParts = append(b.fromParts, Alias(from, alias))
+	b.fromParts = []Sqlizer{Alias(from, alias)}
+	return b
+}
+
+// FromExpr sets the FROM clause of the query to an expression (e.g. Values),
+// replacing tables set before. The expression must include its alias if needed.
+//
+// Ex:
+//     Select("t.id").FromExpr(Values([][]interface{}{{1}, {2}}, "t", "id"))
+//     == "SELECT t.id FROM (VALUES (?),(?)) AS t(id)"
+func (b *SelectBuilder) FromExpr(from Sqlizer) *SelectBuilder {
+	b.fromParts = []Sqlizer{newPart(from)}
+	return b
+}
+
+// TableSample adds a TABLESAMPLE clause to the table added last to the FROM
+// clause, e.g. TableSample("BERNOULLI", 5) produces "big TABLESAMPLE BERNOULLI (5)".
+//
+// TABLESAMPLE is supported by PostgreSQL and SQL Server
+func (b *SelectBuilder) TableSample(method string, percent float64) *SelectBuilder {
+	sample := tableSample{method: method, percent: percent}
+	if n := len(b.fromParts); n > 0 {
+		sample.from = b.fromParts[n-1]
+		b.fromParts[n-1] = sample
+	} else {
+		b.fromParts = append(b.fromParts, sample)
+	}
 	return b
 }
 
+// Repeatable sets seed of the TABLESAMPLE clause set by TableSample, so that
+// the same rows are sampled by each query.
+func (b *SelectBuilder) Repeatable(seed int64) *SelectBuilder {
+	n := len(b.fromParts)
+	if n > 0 {
+		if sample, ok := b.fromParts[n-1].(tableSample); ok {
+			sample.seed = &seed
+			b.fromParts[n-1] = sample
+			return b
+		}
+	}
+	b.fromParts = append(b.fromParts, tableSample{seed: &seed})
+	return b
+}
+
+// tableSample is a table reference with TABLESAMPLE clause
+type tableSample struct {
+	from    Sqlizer
+	method  string
+	percent float64
+	seed    *int64
+}
+
+func (s tableSample) ToSql() (sql string, args []interface{}, err error) {
+	if len(s.method) == 0 {
+		err = fmt.Errorf("select statements must use TableSample to set REPEATABLE seed")
+		return
+	}
+	if s.from == nil {
+		err = fmt.Errorf("select statements must have a table in FROM clause to use TABLESAMPLE")
+		return
+	}
+
+	sql, args, err = s.from.ToSql()
+	if err != nil {
+		return
+	}
+	sql = fmt.Sprintf("%s TABLESAMPLE %s (%s)", sql, s.method, strconv.FormatFloat(s.percent, 'f', -1, 64))
+	if s.seed != nil {
+		sql = fmt.Sprintf("%s REPEATABLE (%d)", sql, *s.seed)
+	}
+	return
+}
+
 // JoinClause adds a join clause to the query.
 func (b *SelectBuilder) JoinClause(pred interface{}, args ...interface{}) *SelectBuilder {
 	b.joins = append(b.joins, newPart(pred, args...))
@@ -271,6 +615,158 @@ func (b *SelectBuilder) RightJoin(join string, rest ...interface{}) *SelectBuild
 	return b.JoinClause("RIGHT JOIN "+join, rest...)
 }
 
+// DeduplicateJoins makes the query skip joins rendered to the same SQL with
+// the same args as one of the previous joins, so that code paths building
+// the query independently can add the joins they need.
+func (b *SelectBuilder) DeduplicateJoins() *SelectBuilder {
+	b.dedupJoins = true
+	return b
+}
+
+// uniqueJoins renders joins dropping those identical to a previous one
+func uniqueJoins(joins []Sqlizer) ([]Sqlizer, error) {
+	unique := make([]Sqlizer, 0, len(joins))
+	for _, join := range joins {
+		joinSql, joinArgs, err := join.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		duplicate := false
+		for _, u := range unique {
+			prev := u.(rawExpr)
+			sameArgs := len(prev.args) == 0 && len(joinArgs) == 0 || reflect.DeepEqual(prev.args, joinArgs)
+			if prev.sql == joinSql && sameArgs {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, Raw(joinSql, joinArgs...))
+		}
+	}
+	return unique, nil
+}
+
+// JoinIf adds a JOIN clause to the query if cond is true.
+//
+// See Join.
+func (b *SelectBuilder) JoinIf(cond bool, join string, rest ...interface{}) *SelectBuilder {
+	if cond {
+		b.Join(join, rest...)
+	}
+	return b
+}
+
+// LeftJoinIf adds a LEFT JOIN clause to the query if cond is true.
+//
+// See LeftJoin.
+func (b *SelectBuilder) LeftJoinIf(cond bool, join string, rest ...interface{}) *SelectBuilder {
+	if cond {
+		b.LeftJoin(join, rest...)
+	}
+	return b
+}
+
+// JoinUsing adds a JOIN ... USING (...) clause to the query.
+func (b *SelectBuilder) JoinUsing(table string, columns ...string) *SelectBuilder {
+	return b.JoinClause("JOIN " + table + usingClause(columns))
+}
+
+// LeftJoinUsing adds a LEFT JOIN ... USING (...) clause to the query.
+func (b *SelectBuilder) LeftJoinUsing(table string, columns ...string) *SelectBuilder {
+	return b.JoinClause("LEFT JOIN " + table + usingClause(columns))
+}
+
+// RightJoinUsing adds a RIGHT JOIN ... USING (...) clause to the query.
+func (b *SelectBuilder) RightJoinUsing(table string, columns ...string) *SelectBuilder {
+	return b.JoinClause("RIGHT JOIN " + table + usingClause(columns))
+}
+
+func usingClause(columns []string) string {
+	return " USING (" + strings.Join(columns, ", ") + ")"
+}
+
+// FullJoin adds a FULL JOIN clause to the query.
+func (b *SelectBuilder) FullJoin(join string, rest ...interface{}) *SelectBuilder {
+	return b.JoinClause("FULL JOIN "+join, rest...)
+}
+
+// CrossJoin adds a CROSS JOIN clause to the query.
+//
+// Unlike other joins, the clause has no join condition, so join only holds
+// the joined table expression and rest are args of its placeholders, like
+// in Join.
+//
+// Ex:
+//     .CrossJoin("generate_series(?::date, ?::date, '1 day') AS d(day)", from, to)
+func (b *SelectBuilder) CrossJoin(join string, rest ...interface{}) *SelectBuilder {
+	return b.JoinClause("CROSS JOIN "+join, rest...)
+}
+
+// JoinLateral adds a JOIN LATERAL clause with subquery sub to the query.
+//
+// The first element of on is a join condition accepting the same types as
+// Where, the rest are its args. When on is omitted the clause is rendered as
+// CROSS JOIN LATERAL.
+//
+// Ex:
+//     .JoinLateral(Select("*").From("orders o").Where("o.user_id = u.id").Limit(3), "o", "true")
+func (b *SelectBuilder) JoinLateral(sub Sqlizer, alias string, on ...interface{}) *SelectBuilder {
+	if len(on) == 0 {
+		return b.JoinClause(lateralJoin{join: "CROSS JOIN", sub: sub, alias: alias})
+	}
+	return b.joinLateral("JOIN", sub, alias, on)
+}
+
+// LeftJoinLateral adds a LEFT JOIN LATERAL clause with subquery sub to the query.
+//
+// See JoinLateral. When on is omitted the join condition defaults to "true".
+func (b *SelectBuilder) LeftJoinLateral(sub Sqlizer, alias string, on ...interface{}) *SelectBuilder {
+	if len(on) == 0 {
+		on = []interface{}{"true"}
+	}
+	return b.joinLateral("LEFT JOIN", sub, alias, on)
+}
+
+func (b *SelectBuilder) joinLateral(join string, sub Sqlizer, alias string, on []interface{}) *SelectBuilder {
+	return b.JoinClause(lateralJoin{join: join, sub: sub, alias: alias, on: newWherePart(on[0], on[1:]...)})
+}
+
+// lateralJoin is a LATERAL join of subquery, alias is written to SQL as is
+type lateralJoin struct {
+	join  string
+	sub   Sqlizer
+	alias string
+	on    Sqlizer
+}
+
+func (j lateralJoin) ToSql() (sqlStr string, args []interface{}, err error) {
+	subSql, args, err := nestedToSql(j.sub)
+	if err != nil {
+		return
+	}
+
+	sql := &bytes.Buffer{}
+	sql.WriteString(j.join)
+	sql.WriteString(" LATERAL (")
+	sql.WriteString(subSql)
+	sql.WriteString(") AS ")
+	sql.WriteString(j.alias)
+
+	if j.on != nil {
+		onSql, onArgs, err := nestedToSql(j.on)
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(" ON ")
+		sql.WriteString(onSql)
+		args = append(args, onArgs...)
+	}
+
+	return sql.String(), args, nil
+}
+
 // Where adds an expression to the WHERE clause of the query.
 //
 // Expressions are ANDed together in the generated SQL.
@@ -296,12 +792,60 @@ func (b *SelectBuilder) Where(pred interface{}, args ...interface{}) *SelectBuil
 	return b
 }
 
+// WhereAll adds each of preds to the WHERE clause of the query, like calling
+// Where for every one of them. Nil preds are skipped.
+// Ex:
+//     filters := []Sqlizer{Eq{"a": 1}, nil, Gt{"b": 2}}
+//     .WhereAll(filters...) == "WHERE a = ? AND b > ?"
+func (b *SelectBuilder) WhereAll(preds ...Sqlizer) *SelectBuilder {
+	for _, pred := range preds {
+		if pred != nil {
+			b.Where(pred)
+		}
+	}
+	return b
+}
+
+// WhereIf adds an expression to the WHERE clause of the query if cond is true.
+//
+// See Where.
+func (b *SelectBuilder) WhereIf(cond bool, pred interface{}, args ...interface{}) *SelectBuilder {
+	if cond {
+		b.Where(pred, args...)
+	}
+	return b
+}
+
 // GroupBy adds GROUP BY expressions to the query.
 func (b *SelectBuilder) GroupBy(groupBys ...string) *SelectBuilder {
 	b.groupBys = append(b.groupBys, groupBys...)
 	return b
 }
 
+// GroupByRollup adds a ROLLUP(...) grouping element to the GROUP BY clause.
+func (b *SelectBuilder) GroupByRollup(columns ...string) *SelectBuilder {
+	return b.GroupBy("ROLLUP(" + strings.Join(columns, ", ") + ")")
+}
+
+// GroupByCube adds a CUBE(...) grouping element to the GROUP BY clause.
+func (b *SelectBuilder) GroupByCube(columns ...string) *SelectBuilder {
+	return b.GroupBy("CUBE(" + strings.Join(columns, ", ") + ")")
+}
+
+// GroupingSets adds a GROUPING SETS (...) element to the GROUP BY clause.
+// Each set is a list of columns, an empty set stands for the grand total.
+//
+// Ex:
+//     .GroupingSets([]string{"region", "product"}, []string{"region"}, nil)
+//     == "GROUP BY GROUPING SETS ((region, product), (region), ())"
+func (b *SelectBuilder) GroupingSets(sets ...[]string) *SelectBuilder {
+	setStrings := make([]string, len(sets))
+	for i, set := range sets {
+		setStrings[i] = "(" + strings.Join(set, ", ") + ")"
+	}
+	return b.GroupBy("GROUPING SETS (" + strings.Join(setStrings, ", ") + ")")
+}
+
 // Having adds an expression to the HAVING clause of the query.
 //
 // See Where.
@@ -310,16 +854,171 @@ func (b *SelectBuilder) Having(pred interface{}, rest ...interface{}) *SelectBui
 	return b
 }
 
+// Window adds a named window definition to the WINDOW clause of the query.
+// Window functions can refer to it with WindowBuilder.Window.
+//
+// Ex:
+//     Select("a").Column(Over("RANK()").Window("w")).From("t").Window("w", Window().OrderBy("a"))
+func (b *SelectBuilder) Window(name string, window *WindowBuilder) *SelectBuilder {
+	b.windows = append(b.windows, Expr(name+" AS ?", window))
+	return b
+}
+
+// Union combines the query with another one using UNION.
+//
+// ORDER BY, LIMIT and OFFSET of the query are applied to the whole compound
+// statement, e.g. "(SELECT ...) UNION (SELECT ...) ORDER BY a LIMIT 10".
+func (b *SelectBuilder) Union(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("UNION", other)
+}
+
+// UnionAll combines the query with another one using UNION ALL.
+//
+// See Union.
+func (b *SelectBuilder) UnionAll(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("UNION ALL", other)
+}
+
+// Intersect combines the query with another one using INTERSECT.
+//
+// See Union.
+func (b *SelectBuilder) Intersect(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("INTERSECT", other)
+}
+
+// IntersectAll combines the query with another one using INTERSECT ALL.
+//
+// See Union.
+func (b *SelectBuilder) IntersectAll(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("INTERSECT ALL", other)
+}
+
+// Except combines the query with another one using EXCEPT.
+//
+// See Union.
+func (b *SelectBuilder) Except(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("EXCEPT", other)
+}
+
+// ExceptAll combines the query with another one using EXCEPT ALL.
+//
+// See Union.
+func (b *SelectBuilder) ExceptAll(other *SelectBuilder) *SelectBuilder {
+	return b.setOp("EXCEPT ALL", other)
+}
+
+func (b *SelectBuilder) setOp(operator string, other *SelectBuilder) *SelectBuilder {
+	b.setOps = append(b.setOps, setOp{operator: operator, query: other})
+	return b
+}
+
 // OrderBy adds ORDER BY expressions to the query.
 func (b *SelectBuilder) OrderBy(orderBys ...string) *SelectBuilder {
-	b.orderBys = append(b.orderBys, orderBys...)
+	for _, orderBy := range orderBys {
+		b.orderBys = append(b.orderBys, newPart(orderBy))
+	}
+	return b
+}
+
+// OrderByClause adds an ORDER BY expression with bound args to the query.
+func (b *SelectBuilder) OrderByClause(pred interface{}, args ...interface{}) *SelectBuilder {
+	b.orderBys = append(b.orderBys, newPart(pred, args...))
+	return b
+}
+
+// OrderByLower adds ORDER BY expressions sorting case-insensitively to the query.
+// Each column is wrapped in LOWER(), trailing ASC or DESC direction is kept
+// outside, e.g. OrderByLower("last_name ASC") produces "LOWER(last_name) ASC".
+func (b *SelectBuilder) OrderByLower(orderBys ...string) *SelectBuilder {
+	for _, orderBy := range orderBys {
+		column, direction := orderBy, ""
+		if i := strings.LastIndex(orderBy, " "); i >= 0 {
+			switch strings.ToUpper(orderBy[i+1:]) {
+			case "ASC", "DESC":
+				column, direction = strings.TrimSpace(orderBy[:i]), orderBy[i:]
+			}
+		}
+		b.orderBys = append(b.orderBys, newPart("LOWER("+column+")"+direction))
+	}
+	return b
+}
+
+// OrderByCollate adds an ORDER BY expression sorting by column using given
+// collation to the query, e.g. OrderByCollate("name", `"de_DE"`, "ASC") produces
+// `name COLLATE "de_DE" ASC`. Collation is used verbatim, direction may be empty.
+func (b *SelectBuilder) OrderByCollate(column, collation, direction string) *SelectBuilder {
+	orderBy := column + " COLLATE " + collation
+	if len(direction) > 0 {
+		orderBy += " " + direction
+	}
+	return b.OrderBy(orderBy)
+}
+
+// OrderByNulls adds an ORDER BY expression with explicit ordering of NULL
+// values to the query, e.g. OrderByNulls("created_at", "DESC", "LAST") produces
+// "created_at DESC NULLS LAST". Direction may be empty.
+//
+// ORDER BY ... NULLS FIRST|LAST is supported by PostgreSQL, Oracle and SQLite
+func (b *SelectBuilder) OrderByNulls(column, direction, nulls string) *SelectBuilder {
+	b.orderBys = append(b.orderBys, orderByNulls{column: column, direction: direction, nulls: nulls})
 	return b
 }
 
+// orderByNulls is an ORDER BY expression with NULLS FIRST or NULLS LAST
+type orderByNulls struct {
+	column    string
+	direction string
+	nulls     string
+}
+
+func (o orderByNulls) ToSql() (sql string, args []interface{}, err error) {
+	nulls := strings.ToUpper(o.nulls)
+	if nulls != "FIRST" && nulls != "LAST" {
+		err = fmt.Errorf("expected FIRST or LAST for NULLS ordering of %s, not %q", o.column, o.nulls)
+		return
+	}
+
+	sql = o.column
+	if len(o.direction) > 0 {
+		sql += " " + o.direction
+	}
+	sql += " NULLS " + nulls
+	return
+}
+
 // Limit sets a LIMIT clause on the query.
 func (b *SelectBuilder) Limit(limit uint64) *SelectBuilder {
 	b.limit = limit
 	b.limitValid = true
+	b.limitAll = false
+	return b
+}
+
+// LimitAll sets a LIMIT ALL clause on the query, which is the same as no limit.
+//
+// LIMIT ALL is PostgreSQL specific
+func (b *SelectBuilder) LimitAll() *SelectBuilder {
+	b.limit = 0
+	b.limitValid = true
+	b.limitAll = true
+	return b
+}
+
+// FetchSyntax sets whether LIMIT and OFFSET are rendered using the SQL standard
+// "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY" syntax with bound args, which is
+// required by SQL Server. OFFSET is always rendered, as FETCH cannot be used
+// without it, and the query must have ORDER BY clause. Enabled by SQLServer
+// dialect, which also makes UpdateBuilder and DeleteBuilder reject Limit and
+// Offset.
+func (b *SelectBuilder) FetchSyntax(enabled bool) *SelectBuilder {
+	b.fetchSyntax = enabled
+	return b
+}
+
+// RemoveLimit removes the LIMIT clause set by Limit.
+func (b *SelectBuilder) RemoveLimit() *SelectBuilder {
+	b.limitValid = false
+	b.limitAll = false
 	return b
 }
 
@@ -330,9 +1029,78 @@ func (b *SelectBuilder) Offset(offset uint64) *SelectBuilder {
 	return b
 }
 
+// RemoveOffset removes the OFFSET clause set by Offset.
+func (b *SelectBuilder) RemoveOffset() *SelectBuilder {
+	b.offsetValid = false
+	return b
+}
+
+// Count returns a new builder counting rows of the query, ignoring its
+// ORDER BY, LIMIT and OFFSET clauses. The query itself is not changed.
+//
+// Ex:
+//     Select("*").From("users").Where("age > ?", 18).OrderBy("id").Limit(10).Count()
+//     == "SELECT COUNT(*) FROM (SELECT * FROM users WHERE age > ?) AS count_query"
+func (b *SelectBuilder) Count() *SelectBuilder {
+	query := b.Clone()
+	query.orderBys = nil
+	query.RemoveLimit().RemoveOffset()
+
+	return NewSelectBuilder(b.StatementBuilderType).
+		Column("COUNT(*)").
+		FromSelect(query, "count_query")
+}
+
+// ForUpdate adds a FOR UPDATE locking clause to the query, replacing any
+// locking clause set before.
+func (b *SelectBuilder) ForUpdate() *SelectBuilder {
+	b.lockStrength = "UPDATE"
+	return b
+}
+
+// ForShare adds a FOR SHARE locking clause to the query, replacing any
+// locking clause set before.
+func (b *SelectBuilder) ForShare() *SelectBuilder {
+	b.lockStrength = "SHARE"
+	return b
+}
+
+// OfTables restricts the locking clause to given tables, e.g. "FOR UPDATE OF t1".
+func (b *SelectBuilder) OfTables(tables ...string) *SelectBuilder {
+	b.lockTables = tables
+	return b
+}
+
+// NoWait adds NOWAIT option to the locking clause.
+func (b *SelectBuilder) NoWait() *SelectBuilder {
+	b.lockWait = "NOWAIT"
+	return b
+}
+
+// SkipLocked adds SKIP LOCKED option to the locking clause.
+func (b *SelectBuilder) SkipLocked() *SelectBuilder {
+	b.lockWait = "SKIP LOCKED"
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *SelectBuilder) Suffix(sql string, args ...interface{}) *SelectBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
 
 	return b
 }
+
+// SuffixExpr adds an expression built by expr to the end of the query.
+// Its args are placed after args of the query.
+func (b *SelectBuilder) SuffixExpr(expr Sqlizer) *SelectBuilder {
+	b.suffixes = append(b.suffixes, newPart(expr))
+	return b
+}
+
+// SuffixArgs appends args to the end of the query args without adding any SQL.
+// It is a low-level escape hatch for placeholders of a suffix composed in
+// pieces, args are placed after args of all other clauses including suffixes.
+func (b *SelectBuilder) SuffixArgs(args ...interface{}) *SelectBuilder {
+	b.suffixArgs = append(b.suffixArgs, args...)
+	return b
+}
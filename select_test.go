@@ -2,6 +2,7 @@ package sqrl
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -96,6 +97,69 @@ func TestSelectBuilderZeroOffsetLimit(t *testing.T) {
 	assert.Equal(t, expectedSql, sql)
 }
 
+func TestSelectBuilderOffsetWithoutLimit(t *testing.T) {
+	sql, args, err := Select("a").From("b").OrderBy("a").Offset(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a OFFSET 10", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = Select("a").From("b").OrderBy("a").Offset(10).FetchSyntax(true).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a OFFSET ? ROWS", sql)
+	assert.Equal(t, []interface{}{uint64(10)}, args)
+}
+
+func TestSelectBuilderLimitAll(t *testing.T) {
+	sql, _, err := Select("a").From("b").LimitAll().Offset(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b LIMIT ALL OFFSET 10", sql)
+
+	sql, _, err = Select("a").From("b").LimitAll().Limit(5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b LIMIT 5", sql)
+}
+
+func TestSelectBuilderFetchSyntax(t *testing.T) {
+	sql, args, err := Select("a").
+		From("b").
+		Where("c = ?", 1).
+		OrderBy("a").
+		Limit(10).
+		Offset(20).
+		FetchSyntax(true).
+		PlaceholderFormat(AtP).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b WHERE c = @p1 ORDER BY a OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY", sql)
+	assert.Equal(t, []interface{}{1, uint64(20), uint64(10)}, args)
+
+	sql, args, err = Select("a").From("b").OrderBy("a").Limit(10).FetchSyntax(true).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", sql)
+	assert.Equal(t, []interface{}{uint64(0), uint64(10)}, args)
+
+	sql, args, err = Select("a").From("b").OrderBy("a").LimitAll().Offset(5).FetchSyntax(true).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b ORDER BY a OFFSET ? ROWS", sql)
+	assert.Equal(t, []interface{}{uint64(5)}, args)
+}
+
+func TestSelectBuilderRemoveLimitOffset(t *testing.T) {
+	paged := Select("a").From("b").Limit(10).Offset(20)
+
+	sql, _, err := paged.Clone().RemoveLimit().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b OFFSET 20", sql)
+
+	sql, _, err = paged.Clone().RemoveOffset().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b LIMIT 10", sql)
+
+	sql, _, err = paged.RemoveLimit().RemoveOffset().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b", sql)
+}
+
 
 func TestSelectBuilderFromSelect(t *testing.T) {
 	subQ := Select("c").From("d").Where(Eq{"i": 0})
@@ -153,6 +217,29 @@ func TestSelectBuilderRunners(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSelectBuilderRunWithContext(t *testing.T) {
+	db := &DBStub{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := Select("test").RunWithContext(ctx, db)
+
+	b.Exec()
+	assert.Equal(t, ctx, db.LastContext)
+
+	db.LastContext = nil
+	b.Query()
+	assert.Equal(t, ctx, db.LastContext)
+
+	db.LastContext = nil
+	b.Scan()
+	assert.Equal(t, context.Canceled, db.LastContext.Err())
+
+	other := context.WithValue(context.Background(), "key", "value")
+	b.ExecContext(other)
+	assert.Equal(t, other, db.LastContext)
+}
+
 func TestSelectBuilderNoRunner(t *testing.T) {
 	b := Select("test")
 
@@ -219,3 +306,539 @@ func TestSelectWithOptions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "SELECT DISTINCT SQL_NO_CACHE * FROM foo", sql)
 }
+
+func TestSelectBuilderUnion(t *testing.T) {
+	b := Select("a").From("t1").Where("x = ?", 1).
+		Union(Select("a").From("t2").Where("y = ?", 2)).
+		UnionAll(Select("a").From("t3").Where("z = ?", 3)).
+		OrderBy("a").
+		Limit(10).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(SELECT a FROM t1 WHERE x = $1) " +
+		"UNION (SELECT a FROM t2 WHERE y = $2) " +
+		"UNION ALL (SELECT a FROM t3 WHERE z = $3) " +
+		"ORDER BY a LIMIT 10"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestSelectBuilderUnionNestedPlaceholders(t *testing.T) {
+	sb := StatementBuilder.PlaceholderFormat(Dollar)
+	b := sb.Select("a").From("t1").Where("x = ?", 1).
+		Union(sb.Select("a").From("t2").Where("y = ? OR y = ?", 2, 3))
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(SELECT a FROM t1 WHERE x = $1) UNION (SELECT a FROM t2 WHERE y = $2 OR y = $3)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestSelectBuilderIntersectExcept(t *testing.T) {
+	b := Select("id").From("a").
+		Except(Select("id").From("b").Where("flagged = ?", true)).
+		IntersectAll(Select("id").From("c")).
+		ExceptAll(Select("id").From("d")).
+		Intersect(Select("id").From("e"))
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(SELECT id FROM a) " +
+		"EXCEPT (SELECT id FROM b WHERE flagged = ?) " +
+		"INTERSECT ALL (SELECT id FROM c) " +
+		"EXCEPT ALL (SELECT id FROM d) " +
+		"INTERSECT (SELECT id FROM e)"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{true}, args)
+}
+
+func TestSelectBuilderSetOpErr(t *testing.T) {
+	_, _, err := Select("id").From("a").Except(Select().From("b")).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Select("id").From("a").Union(nil).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderWith(t *testing.T) {
+	b := Select("*").
+		With("recent", Select("id").From("orders").Where("created_at > ?", 10)).
+		WithColumns("totals", []string{"id", "total"}, Select("order_id", "SUM(amount)").From("items").GroupBy("order_id")).
+		From("recent").
+		Join("totals USING (id)").
+		Where("total > ?", 20).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "WITH recent AS (SELECT id FROM orders WHERE created_at > $1), " +
+		"totals(id, total) AS (SELECT order_id, SUM(amount) FROM items GROUP BY order_id) " +
+		"SELECT * FROM recent JOIN totals USING (id) WHERE total > $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{10, 20}, args)
+}
+
+func TestSelectBuilderWithRecursive(t *testing.T) {
+	cte := Select("1").
+		UnionAll(Select("n + 1").From("t").Where("n < ?", 100))
+	b := Select("SUM(n)").WithRecursive("t(n)", cte).From("t")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "WITH RECURSIVE t(n) AS ((SELECT 1) UNION ALL (SELECT n + 1 FROM t WHERE n < ?)) " +
+		"SELECT SUM(n) FROM t"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{100}, args)
+}
+
+func TestSelectBuilderWithJoinedTwice(t *testing.T) {
+	managers := Select("id", "name").From("users").Where("role = ?", "manager")
+	b := Select("p.id", "a.name AS author", "r.name AS reviewer").
+		With("managers", managers).
+		From("posts p").
+		Join("managers a ON a.id = p.author_id").
+		LeftJoin("managers r ON r.id = p.reviewer_id").
+		Where("p.status = ?", "published").
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "WITH managers AS (SELECT id, name FROM users WHERE role = $1) " +
+		"SELECT p.id, a.name AS author, r.name AS reviewer FROM posts p " +
+		"JOIN managers a ON a.id = p.author_id " +
+		"LEFT JOIN managers r ON r.id = p.reviewer_id " +
+		"WHERE p.status = $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"manager", "published"}, args)
+
+	_, _, err = b.WithColumns("managers", []string{"id"}, Select("1")).ToSql()
+	assert.EqualError(t, err, "duplicate common table expression managers")
+
+	_, _, err = Select("*").From("t").With("t", Select("1")).WithRecursive("t(n)", Select("1")).ToSql()
+	assert.EqualError(t, err, "duplicate common table expression t")
+}
+
+func TestSelectBuilderDistinctOn(t *testing.T) {
+	b := Select("user_id", "created_at AS last_seen").
+		DistinctOn("user_id", "device").
+		From("visits").
+		OrderBy("user_id", "device", "created_at DESC")
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT DISTINCT ON (user_id, device) user_id, created_at AS last_seen FROM visits "+
+		"ORDER BY user_id, device, created_at DESC", sql)
+
+	_, _, err = b.Distinct().ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderLocking(t *testing.T) {
+	b := Select("*").From("users").Where("id = ?", 1).
+		ForUpdate().
+		ForShare().
+		OfTables("users").
+		NoWait()
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ? FOR SHARE OF users NOWAIT", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	b = Select("*").From("jobs").OrderBy("id").Limit(1).ForUpdate().SkipLocked()
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM jobs ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED", sql)
+
+	_, _, err = Select("*").From("jobs").SkipLocked().ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderFullAndCrossJoin(t *testing.T) {
+	b := Select("d.day", "e.name").
+		From("employees e").
+		CrossJoin("generate_series(?::date, ?::date, '1 day') AS d(day)", "2020-01-01", "2020-01-31").
+		FullJoin("shifts s ON s.employee_id = e.id AND s.day = d.day AND s.kind = ?", "night").
+		Where("e.active = ?", true)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT d.day, e.name FROM employees e " +
+		"CROSS JOIN generate_series(?::date, ?::date, '1 day') AS d(day) " +
+		"FULL JOIN shifts s ON s.employee_id = e.id AND s.day = d.day AND s.kind = ? " +
+		"WHERE e.active = ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"2020-01-01", "2020-01-31", "night", true}, args)
+}
+
+func TestSelectBuilderJoinLateral(t *testing.T) {
+	recent := Select("o.total").From("orders o").Where("o.user_id = u.id AND o.status = ?", "paid").Limit(3)
+
+	b := Select("u.name", "r.total").
+		From("users u").
+		JoinLateral(recent, "r", "r.total > ?", 100).
+		Where("u.active = ?", true).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT u.name, r.total FROM users u " +
+		"JOIN LATERAL (SELECT o.total FROM orders o WHERE o.user_id = u.id AND o.status = $1 LIMIT 3) AS r ON r.total > $2 " +
+		"WHERE u.active = $3"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"paid", 100, true}, args)
+
+	b = Select("u.name", "r.total").
+		From("users u").
+		JoinLateral(recent, "r").
+		LeftJoinLateral(Select("1").From("bans b").Where("b.user_id = u.id"), "b")
+
+	sql, args, err = b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql = "SELECT u.name, r.total FROM users u " +
+		"CROSS JOIN LATERAL (SELECT o.total FROM orders o WHERE o.user_id = u.id AND o.status = ? LIMIT 3) AS r " +
+		"LEFT JOIN LATERAL (SELECT 1 FROM bans b WHERE b.user_id = u.id) AS b ON true"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"paid"}, args)
+
+	sql, args, err = Select("*").From("users u").JoinLateral(recent, `"r?"`, "r.total > ?", 100).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users u "+
+		`JOIN LATERAL (SELECT o.total FROM orders o WHERE o.user_id = u.id AND o.status = ? LIMIT 3) AS "r?" ON r.total > ?`, sql)
+	assert.Equal(t, []interface{}{"paid", 100}, args)
+}
+
+func TestSelectBuilderJoinUsing(t *testing.T) {
+	b := Select("*").
+		From("users").
+		JoinUsing("accounts", "account_id").
+		LeftJoinUsing("addresses", "user_id").
+		RightJoinUsing("regions", "region_id", "country_id").
+		Join("roles ON roles.id = users.role_id AND roles.name = ?", "admin")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM users " +
+		"JOIN accounts USING (account_id) " +
+		"LEFT JOIN addresses USING (user_id) " +
+		"RIGHT JOIN regions USING (region_id, country_id) " +
+		"JOIN roles ON roles.id = users.role_id AND roles.name = ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"admin"}, args)
+}
+
+func TestSelectBuilderFromSelectNested(t *testing.T) {
+	sb := StatementBuilder.PlaceholderFormat(Dollar)
+
+	inner := sb.Select("id", "amount").From("payments").Where("status = ?", "paid")
+	middle := sb.Select("id", "SUM(amount) AS total").FromSelect(inner, "p").Where("amount > ?", 10).GroupBy("id")
+	b := sb.Select("*").From("ignored").FromSelect(middle, "t").Where("total < ?", 1000)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM (" +
+		"SELECT id, SUM(amount) AS total FROM (" +
+		"SELECT id, amount FROM payments WHERE status = $1" +
+		") AS p WHERE amount > $2 GROUP BY id" +
+		") AS t WHERE total < $3"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"paid", 10, 1000}, args)
+}
+
+func TestSelectBuilderOrderByNulls(t *testing.T) {
+	b := Select("*").From("posts").
+		OrderBy("pinned DESC").
+		OrderByNulls("published_at", "DESC", "last").
+		OrderByClause("id <> ?", 42).
+		OrderByNulls("title", "", "FIRST")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM posts ORDER BY pinned DESC, published_at DESC NULLS LAST, id <> ?, title NULLS FIRST", sql)
+	assert.Equal(t, []interface{}{42}, args)
+
+	_, _, err = Select("*").From("posts").OrderByNulls("published_at", "DESC", "END").ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderGroupingElements(t *testing.T) {
+	b := Select("region", "product", "SUM(amount)").From("sales").GroupByRollup("region", "product")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT region, product, SUM(amount) FROM sales GROUP BY ROLLUP(region, product)", sql)
+	assert.Empty(t, args)
+
+	b = Select("year", "region", "product", "SUM(amount)").From("sales").
+		GroupBy("year").
+		GroupByCube("region", "product").
+		GroupingSets([]string{"region", "product"}, []string{"region"}, nil)
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT year, region, product, SUM(amount) FROM sales "+
+		"GROUP BY year, CUBE(region, product), GROUPING SETS ((region, product), (region), ())", sql)
+}
+
+func TestSelectBuilderClone(t *testing.T) {
+	base := Select("id").From("users").Where("deleted_at IS NULL")
+
+	paged := base.Clone().Column("name").Where("age > ?", 18).OrderBy("id").Limit(10)
+	count := base.Clone().Where("active = ?", true)
+
+	sql, args, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE deleted_at IS NULL", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = paged.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, name FROM users WHERE deleted_at IS NULL AND age > ? ORDER BY id LIMIT 10", sql)
+	assert.Equal(t, []interface{}{18}, args)
+
+	sql, args, err = count.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE deleted_at IS NULL AND active = ?", sql)
+	assert.Equal(t, []interface{}{true}, args)
+}
+
+func TestSelectBuilderCloneSpareCapacity(t *testing.T) {
+	base := Select("id").From("users").Where("a = ?", 1).Where("b = ?", 2).Where("c = ?", 3)
+	assert.True(t, cap(base.whereParts) > len(base.whereParts))
+
+	left := base.Clone().Where("left = ?", "l")
+	right := base.Clone().Where("right = ?", "r")
+	base.Where("base = ?", "b")
+
+	sql, args, err := left.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE a = ? AND b = ? AND c = ? AND left = ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, "l"}, args)
+
+	sql, args, err = right.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE a = ? AND b = ? AND c = ? AND right = ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, "r"}, args)
+
+	sql, args, err = base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE a = ? AND b = ? AND c = ? AND base = ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, "b"}, args)
+}
+
+func TestSelectBuilderCount(t *testing.T) {
+	b := Select("u.id", "u.name").
+		From("users u").
+		Join("emails e ON e.user_id = u.id AND e.verified = ?", true).
+		Where("u.age > ?", 18).
+		GroupBy("u.id").
+		Having("COUNT(e.id) > ?", 1).
+		OrderBy("u.name").
+		Limit(10).
+		Offset(20).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.id, u.name FROM users u "+
+		"JOIN emails e ON e.user_id = u.id AND e.verified = $1 "+
+		"WHERE u.age > $2 GROUP BY u.id HAVING COUNT(e.id) > $3 "+
+		"ORDER BY u.name LIMIT 10 OFFSET 20", sql)
+
+	countSql, countArgs, err := b.Count().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT u.id, u.name FROM users u "+
+		"JOIN emails e ON e.user_id = u.id AND e.verified = $1 "+
+		"WHERE u.age > $2 GROUP BY u.id HAVING COUNT(e.id) > $3) AS count_query", countSql)
+	assert.Equal(t, args, countArgs)
+
+	sqlAfter, _, _ := b.ToSql()
+	assert.Equal(t, sql, sqlAfter)
+}
+
+func TestSelectBuilderOrderByLower(t *testing.T) {
+	sql, _, err := Select("*").
+		From("users").
+		OrderBy("active DESC").
+		OrderByLower("last_name ASC", "first_name", "nick desc").
+		OrderBy("id").
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users ORDER BY active DESC, LOWER(last_name) ASC, LOWER(first_name), LOWER(nick) desc, id", sql)
+}
+
+func TestSelectBuilderConditional(t *testing.T) {
+	name, minAge := "foo", 0
+	withEmail := false
+
+	sql, args, err := Select("id").
+		ColumnIf(true, "name").
+		ColumnIf(withEmail, "email").
+		ColumnIf(true, "age > ? AS adult", 18).
+		From("users").
+		WhereIf(name != "", Eq{"name": name}).
+		WhereIf(minAge > 0, "age >= ?", minAge).
+		WhereIf(true, "active = ?", true).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, name, age > ? AS adult FROM users WHERE name = ? AND active = ?", sql)
+	assert.Equal(t, []interface{}{18, "foo", true}, args)
+}
+
+func TestSelectBuilderJoinIf(t *testing.T) {
+	query := func(tag string) *SelectBuilder {
+		return Select("p.id").
+			From("posts p").
+			JoinIf(tag != "", "post_tags t ON t.post_id = p.id AND t.tag = ?", tag).
+			LeftJoinIf(tag != "", "tag_stats s ON s.tag = t.tag").
+			WhereIf(tag != "", "s.hidden = ?", false)
+	}
+
+	sql, args, err := query("go").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT p.id FROM posts p JOIN post_tags t ON t.post_id = p.id AND t.tag = ? "+
+		"LEFT JOIN tag_stats s ON s.tag = t.tag WHERE s.hidden = ?", sql)
+	assert.Equal(t, []interface{}{"go", false}, args)
+
+	sql, args, err = query("").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT p.id FROM posts p", sql)
+	assert.Empty(t, args)
+}
+
+func TestSelectBuilderDeduplicateJoins(t *testing.T) {
+	b := Select("u.id").
+		From("users u").
+		LeftJoin("addresses a ON a.user_id = u.id").
+		Join("orders o ON o.user_id = u.id AND o.status = ?", "paid").
+		LeftJoin("addresses a ON a.user_id = u.id").
+		Join("orders o ON o.user_id = u.id AND o.status = ?", "paid").
+		Join("orders o ON o.user_id = u.id AND o.status = ?", "new")
+
+	sql, args, err := b.Clone().DeduplicateJoins().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.id FROM users u "+
+		"LEFT JOIN addresses a ON a.user_id = u.id "+
+		"JOIN orders o ON o.user_id = u.id AND o.status = ? "+
+		"JOIN orders o ON o.user_id = u.id AND o.status = ?", sql)
+	assert.Equal(t, []interface{}{"paid", "new"}, args)
+
+	sql, args, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, strings.Count(sql, "JOIN"))
+	assert.Equal(t, []interface{}{"paid", "paid", "new"}, args)
+}
+
+func TestSelectBuilderWhereAll(t *testing.T) {
+	filters := []Sqlizer{Eq{"a": 1}, nil, Expr("b > ?", 2), nil}
+
+	sql, args, err := Select("*").From("t").Where("c = ?", 3).WhereAll(filters...).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE c = ? AND a = ? AND b > ?", sql)
+	assert.Equal(t, []interface{}{3, 1, 2}, args)
+
+	sql, args, err = Select("*").From("t").WhereAll().WhereAll(nil).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t", sql)
+	assert.Empty(t, args)
+}
+
+func TestSelectBuilderPrefixSuffixExpr(t *testing.T) {
+	sql, args, err := Select("*").
+		PrefixExpr(Expr("WITH recent AS (?)", Select("id").From("posts").Where("created_at > ?", "2020-01-01"))).
+		From("recent").
+		Where("id > ?", 10).
+		SuffixExpr(Expr("FETCH FIRST ? ROWS WITH TIES OFFSET ?", 5, 20)).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "WITH recent AS (SELECT id FROM posts WHERE created_at > $1) " +
+		"SELECT * FROM recent WHERE id > $2 " +
+		"FETCH FIRST $3 ROWS WITH TIES OFFSET $4"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"2020-01-01", 10, 5, 20}, args)
+}
+
+func TestSelectBuilderSuffixExprError(t *testing.T) {
+	_, _, err := Select("*").From("t").SuffixExpr(Select()).ToSql()
+	assert.EqualError(t, err, "select statements must have at least one result column")
+}
+
+func TestSelectBuilderSuffixArgs(t *testing.T) {
+	b := Select("*").From("users").Where("id > ?", 1)
+	b.Suffix("LIMIT 5 OFFSET ?")
+	b.SuffixArgs(10)
+	sql, args, err := b.PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM users WHERE id > $1 LIMIT 5 OFFSET $2", sql)
+	assert.Equal(t, []interface{}{1, 10}, args)
+
+	c := b.Clone().Suffix("FOR UPDATE").SuffixArgs(20)
+	_, args, _ = b.ToSql()
+	assert.Equal(t, []interface{}{1, 10}, args)
+	_, args, _ = c.ToSql()
+	assert.Equal(t, []interface{}{1, 10, 20}, args)
+}
+
+func TestSelectBuilderHavingMap(t *testing.T) {
+	sql, args, err := Select("user_id", "COUNT(*)").
+		From("orders").
+		GroupBy("user_id").
+		Having(Gt{"COUNT(*)": 5}).
+		Having(LtOrEq{"SUM(total)": 1000, "MAX(total)": 500}).
+		Having(Eq{"MIN(status)": "paid"}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT user_id, COUNT(*) FROM orders GROUP BY user_id " +
+		"HAVING COUNT(*) > $1 AND MAX(total) <= $2 AND SUM(total) <= $3 AND MIN(status) = $4"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{5, 500, 1000, "paid"}, args)
+}
+
+func TestSelectBuilderTableSample(t *testing.T) {
+	sql, args, err := Select("*").
+		From("big").
+		TableSample("BERNOULLI", 5).
+		Join("small s ON s.id = big.small_id").
+		Where("big.x > ?", 1).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM big TABLESAMPLE BERNOULLI (5) JOIN small s ON s.id = big.small_id WHERE big.x > ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	b := Select("*").From("a", "big b").TableSample("SYSTEM", 0.5)
+	sql, _, err = b.Clone().Repeatable(42).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a, big b TABLESAMPLE SYSTEM (0.5) REPEATABLE (42)", sql)
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a, big b TABLESAMPLE SYSTEM (0.5)", sql)
+}
+
+func TestSelectBuilderTableSampleErrors(t *testing.T) {
+	_, _, err := Select("*").TableSample("SYSTEM", 10).ToSql()
+	assert.EqualError(t, err, "select statements must have a table in FROM clause to use TABLESAMPLE")
+
+	_, _, err = Select("*").From("big").Repeatable(1).ToSql()
+	assert.EqualError(t, err, "select statements must use TableSample to set REPEATABLE seed")
+}
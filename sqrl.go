@@ -1,5 +1,9 @@
 // package sqrl provides a fluent SQL generator.
 //
+// Builder methods modify the builder in place and return it for chaining,
+// so a builder must not be shared between goroutines or queries. Use Clone
+// to derive independent queries from a common base.
+//
 // See https://github.com/elgris/sqrl for examples.
 package sqrl
 
@@ -3,6 +3,9 @@ package sqrl
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +15,8 @@ type DBStub struct {
 	res sql.Result
 	err error
 
+	LastContext context.Context
+
 	LastPrepareSql string
 	PrepareCount   int
 
@@ -32,6 +37,7 @@ func (s *DBStub) Prepare(query string) (*sql.Stmt, error) {
 }
 
 func (s *DBStub) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	s.LastContext = ctx
 	s.LastPrepareSql = query
 	s.PrepareCount++
 	return nil, nil
@@ -44,6 +50,7 @@ func (s *DBStub) Exec(query string, args ...interface{}) (sql.Result, error) {
 }
 
 func (s *DBStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	s.LastContext = ctx
 	s.LastExecSql = query
 	s.LastExecArgs = args
 	return s.res, s.err
@@ -56,6 +63,7 @@ func (s *DBStub) Query(query string, args ...interface{}) (RowsScanner, error) {
 }
 
 func (s *DBStub) QueryContext(ctx context.Context, query string, args ...interface{}) (RowsScanner, error) {
+	s.LastContext = ctx
 	s.LastQuerySql = query
 	s.LastQueryArgs = args
 	return nil, nil
@@ -68,6 +76,7 @@ func (s *DBStub) QueryRow(query string, args ...interface{}) RowScanner {
 }
 
 func (s *DBStub) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	s.LastContext = ctx
 	s.LastQueryRowSql = query
 	s.LastQueryRowArgs = args
 	return &Row{RowScanner: &RowStub{}}
@@ -87,6 +96,116 @@ func (r *resultStub) LastInsertId() (int64, error) {
 	return r.lastInsertId, r.err
 }
 
+// stubDriver is a database/sql driver recording prepared and closed statements,
+// queries return columns and rows set on the driver
+type stubDriver struct {
+	mu       sync.Mutex
+	prepared []string
+	closed   []string
+
+	columns []string
+	rows    [][]driver.Value
+}
+
+func newStubDB() (*sql.DB, *stubDriver) {
+	d := &stubDriver{}
+	return sql.OpenDB(d), d
+}
+
+func (d *stubDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return &stubConn{d: d}, nil
+}
+
+func (d *stubDriver) Driver() driver.Driver {
+	return d
+}
+
+func (d *stubDriver) Open(name string) (driver.Conn, error) {
+	return &stubConn{d: d}, nil
+}
+
+func (d *stubDriver) Prepared() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.prepared...)
+}
+
+func (d *stubDriver) Closed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.closed...)
+}
+
+type stubConn struct {
+	d *stubDriver
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.prepared = append(c.d.prepared, query)
+	return &stubStmt{d: c.d, query: query}, nil
+}
+
+func (c *stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return stubTx{}, nil
+}
+
+type stubStmt struct {
+	d     *stubDriver
+	query string
+}
+
+func (s *stubStmt) Close() error {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.closed = append(s.d.closed, s.query)
+	return nil
+}
+
+func (s *stubStmt) NumInput() int {
+	return -1
+}
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &stubRows{columns: s.d.columns, rows: s.d.rows}, nil
+}
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	return r.columns
+}
+
+func (r *stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type stubTx struct{}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
 var sqlizer = Select("test")
 var sqlStr = "SELECT test"
 
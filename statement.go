@@ -1,9 +1,16 @@
 package sqrl
 
+import "context"
+
 // StatementBuilderType is the type of StatementBuilder.
 type StatementBuilderType struct {
 	placeholderFormat PlaceholderFormat
 	runWith           Runner
+	ctx               context.Context
+	quote             Quote
+	fetchSyntax       bool
+	castShorthand     bool
+	noPostgresClauses bool
 }
 
 // Select returns a SelectBuilder for this StatementBuilder.
@@ -26,6 +33,21 @@ func (b StatementBuilderType) Delete(what ...string) *DeleteBuilder {
 	return NewDeleteBuilder(b).What(what...)
 }
 
+// Truncate returns a TruncateBuilder for this StatementBuilder.
+func (b StatementBuilderType) Truncate(tables ...string) *TruncateBuilder {
+	return NewTruncateBuilder(b).Table(tables...)
+}
+
+// CreateTableAs returns a CreateTableAsBuilder for this StatementBuilder.
+func (b StatementBuilderType) CreateTableAs(table string, query *SelectBuilder) *CreateTableAsBuilder {
+	return NewCreateTableAsBuilder(b).Table(table).As(query)
+}
+
+// Merge returns a MergeBuilder for this StatementBuilder.
+func (b StatementBuilderType) Merge(target string) *MergeBuilder {
+	return NewMergeBuilder(b).Into(target)
+}
+
 // PlaceholderFormat sets the PlaceholderFormat field for any child builders.
 func (b StatementBuilderType) PlaceholderFormat(f PlaceholderFormat) StatementBuilderType {
 	b.placeholderFormat = f
@@ -38,10 +60,68 @@ func (b StatementBuilderType) RunWith(runner BaseRunner) StatementBuilderType {
 	return b
 }
 
+// RunWithContext sets the RunWith field and the default context for any child builders.
+func (b StatementBuilderType) RunWithContext(ctx context.Context, runner BaseRunner) StatementBuilderType {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
+// QuoteWith sets the quoting style of identifiers returned by Ident.
+func (b StatementBuilderType) QuoteWith(q Quote) StatementBuilderType {
+	b.quote = q
+	return b
+}
+
+// Ident returns identifier name quoted as set by QuoteWith, defaulting to
+// double quotes.
+//
+// Builders cannot tell identifiers from expressions, so only names wrapped
+// with Ident are quoted.
+func (b StatementBuilderType) Ident(name string) identExpr {
+	return identExpr{name: name, quote: b.quote}
+}
+
+// Cast returns expression converting operand to typ, using PostgreSQL
+// operand::typ shorthand if enabled by the dialect, or CAST(operand AS typ)
+// otherwise.
+//
+// See Cast.
+func (b StatementBuilderType) Cast(operand interface{}, typ string) castExpr {
+	return castExpr{operand: operand, typ: typ, shorthand: b.castShorthand}
+}
+
+// runContext returns the context set by RunWithContext or context.Background
+func (b StatementBuilderType) runContext() context.Context {
+	if b.ctx != nil {
+		return b.ctx
+	}
+	return context.Background()
+}
+
 // StatementBuilder is a basic statement builder, holds global configuration options
 // like placeholder format or SQL runner
 var StatementBuilder = StatementBuilderType{placeholderFormat: Question}
 
+// SetDefaultPlaceholderFormat sets the placeholder format of StatementBuilder
+// used by package-level constructors like Select and Insert.
+//
+// It changes global state of the package, affecting all its users in the
+// process, and it is not safe for concurrent use with the constructors, so it
+// must only be called during program initialization, e.g. from init.
+// Builders created before the call keep their format.
+func SetDefaultPlaceholderFormat(f PlaceholderFormat) {
+	StatementBuilder = StatementBuilder.PlaceholderFormat(f)
+}
+
+// SetDefaultDialect sets the dialect of StatementBuilder used by package-level
+// constructors like Select and Insert.
+//
+// Like SetDefaultPlaceholderFormat, it must only be called during program
+// initialization.
+func SetDefaultDialect(d Dialect) {
+	StatementBuilder = StatementBuilder.Dialect(d)
+}
+
 // Select returns a new SelectBuilder, optionally setting some result columns.
 //
 // See SelectBuilder.Columns.
@@ -70,6 +150,28 @@ func Delete(what ...string) *DeleteBuilder {
 	return StatementBuilder.Delete(what...)
 }
 
+// Truncate returns a new TruncateBuilder for given table names.
+//
+// See TruncateBuilder.Table.
+func Truncate(tables ...string) *TruncateBuilder {
+	return StatementBuilder.Truncate(tables...)
+}
+
+// CreateTableAs returns a new CreateTableAsBuilder creating table from
+// results of query.
+//
+// See CreateTableAsBuilder.As.
+func CreateTableAs(table string, query *SelectBuilder) *CreateTableAsBuilder {
+	return StatementBuilder.CreateTableAs(table, query)
+}
+
+// Merge returns a new MergeBuilder with the given target table name.
+//
+// See MergeBuilder.Into.
+func Merge(target string) *MergeBuilder {
+	return StatementBuilder.Merge(target)
+}
+
 // Case returns a new CaseBuilder
 // "what" represents case value
 func Case(what ...interface{}) *CaseBuilder {
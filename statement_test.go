@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -23,6 +24,22 @@ func TestStatementBuilderPlaceholderFormat(t *testing.T) {
 	assert.Equal(t, "SELECT test WHERE x = $1", db.LastExecSql)
 }
 
+func TestStatementBuilderRunWithContext(t *testing.T) {
+	db := &DBStub{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sb := StatementBuilder.RunWithContext(ctx, db)
+
+	sb.Update("test").Set("x", 1).Exec()
+	assert.Equal(t, ctx, db.LastContext)
+
+	sb.Delete("test").Exec()
+	assert.Equal(t, ctx, db.LastContext)
+
+	sb.Insert("test").Values(1).Exec()
+	assert.Equal(t, ctx, db.LastContext)
+}
+
 func TestRunWithDB(t *testing.T) {
 	db := &sql.DB{}
 	assert.NotPanics(t, func() {
@@ -43,3 +60,23 @@ func TestRunWithTx(t *testing.T) {
 		Delete("t").RunWith(tx)
 	}, "RunWith(*sql.Tx) should not panic")
 }
+
+func TestSetDefaultPlaceholderFormat(t *testing.T) {
+	defer func(b StatementBuilderType) { StatementBuilder = b }(StatementBuilder)
+
+	before := Select("*").From("users").Where("id = ?", 1)
+
+	SetDefaultPlaceholderFormat(Dollar)
+	sql, _, err := Select("*").From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", sql)
+
+	sql, _, err = before.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?", sql)
+
+	SetDefaultDialect(SQLServer)
+	sql, _, err = Select().Column(Ident("name")).From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT [name] FROM users WHERE id = @p1", sql)
+}
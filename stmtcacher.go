@@ -1,14 +1,20 @@
 package sqrl
 
 import (
+	"container/list"
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
 
 const maxAge = 4 * time.Hour
 
+// ErrStmtCacheClosed is returned when preparing statements with closed StmtCache.
+var ErrStmtCacheClosed = fmt.Errorf("cannot prepare; statement cache is closed")
+
 // Preparer is the interface that wraps the Prepare method.
 //
 // Prepare executes the given query as implemented by database/sql.Prepare.
@@ -29,81 +35,346 @@ type DBProxy interface {
 	QueryRowerContext
 }
 
+// StmtCache is a DBProxy that caches Prepared Stmts.
+//
+// Close closes all cached Stmts, StmtCache cannot be used after Close.
+// Len and Queries report currently cached Stmts.
+type StmtCache interface {
+	DBProxy
+	Close() error
+	Len() int
+	Queries() []string
+}
+
 type savedStmt struct {
+	query      string
 	stmt       *sql.Stmt
 	expiration *time.Timer
+	lastUsed   time.Time
+	elem       *list.Element
+	refs       int
+	evicted    bool
 }
 
 type stmtCacher struct {
-	prep  Preparer
-	cache map[string]*savedStmt
-	mu    sync.Mutex
+	prep       Preparer
+	cache      map[string]*savedStmt
+	lru        *list.List
+	maxEntries int
+	ttl        time.Duration
+	closed     bool
+	onHit      func(query string)
+	onMiss     func(query string)
+	mu         sync.Mutex
+}
+
+// StmtCacherOption configures statement cache created by NewStmtCacher and friends.
+type StmtCacherOption func(*stmtCacher)
+
+// WithCacheHooks sets functions called with the query on every cache hit and miss.
+// Misses are reported only once the Stmt is prepared successfully.
+// Either function may be nil.
+func WithCacheHooks(onHit, onMiss func(query string)) StmtCacherOption {
+	return func(sc *stmtCacher) {
+		sc.onHit = onHit
+		sc.onMiss = onMiss
+	}
 }
 
 // NewStmtCacher returns a DBProxy wrapping prep that caches Prepared Stmts.
 //
 // Stmts are cached based on the string value of their queries.
-func NewStmtCacher(prep Preparer) DBProxy {
-	return &stmtCacher{prep: prep, cache: make(map[string]*savedStmt)}
+func NewStmtCacher(prep Preparer, opts ...StmtCacherOption) StmtCache {
+	return NewStmtCacherLRU(prep, 0, opts...)
+}
+
+// NewStmtCacherLRU returns a DBProxy wrapping prep that caches at most
+// maxEntries Prepared Stmts. Once the limit is reached, the least recently
+// used Stmt is closed and removed from the cache. maxEntries <= 0 means
+// there is no limit.
+func NewStmtCacherLRU(prep Preparer, maxEntries int, opts ...StmtCacherOption) StmtCache {
+	return newStmtCacher(prep, maxEntries, maxAge, opts)
+}
+
+// NewStmtCacherWithTTL returns a DBProxy wrapping prep that caches Prepared Stmts
+// and closes them once they were not used for ttl. ttl <= 0 means Stmts
+// never expire.
+func NewStmtCacherWithTTL(prep Preparer, ttl time.Duration, opts ...StmtCacherOption) StmtCache {
+	return newStmtCacher(prep, 0, ttl, opts)
 }
 
-func (sc *stmtCacher) remove(query string) func() {
-	return func() {
-		sc.mu.Lock()
-		defer sc.mu.Unlock()
-		if s, ok := sc.cache[query]; ok {
-			s.stmt.Close()
+func newStmtCacher(prep Preparer, maxEntries int, ttl time.Duration, opts []StmtCacherOption) *stmtCacher {
+	sc := &stmtCacher{
+		prep:       prep,
+		cache:      make(map[string]*savedStmt),
+		lru:        list.New(),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
+}
+
+// expire evicts the Stmt if it was not used for ttl, otherwise the
+// expiration is rescheduled. Hits only update lastUsed, so the timer
+// is never stopped or drained while the callback may be waiting for sc.mu.
+func (sc *stmtCacher) expire(s *savedStmt) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.cache[s.query] != s {
+		return
+	}
+	if idle := time.Since(s.lastUsed); idle < sc.ttl {
+		s.expiration.Reset(sc.ttl - idle)
+		return
+	}
+	sc.evict(s)
+}
+
+// evict removes the Stmt from the cache and closes it, sc.mu must be held.
+// Stmt still used by running queries is closed once the last one releases it.
+func (sc *stmtCacher) evict(s *savedStmt) error {
+	if s.expiration != nil {
+		s.expiration.Stop()
+	}
+	sc.lru.Remove(s.elem)
+	delete(sc.cache, s.query)
+	s.evicted = true
+	if s.refs > 0 {
+		return nil
+	}
+	return s.stmt.Close()
+}
+
+// release marks the Stmt acquired by acquire as no longer used by the caller
+// and closes it if it was evicted meanwhile.
+func (sc *stmtCacher) release(s *savedStmt) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	s.refs--
+	if s.evicted && s.refs == 0 {
+		s.stmt.Close()
+	}
+}
+
+// Close stops expiration of all cached Stmts and closes them. Stmts used by
+// running queries are closed once the queries finish.
+func (sc *stmtCacher) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.closed = true
+
+	var errs []string
+	for _, s := range sc.cache {
+		if err := sc.evict(s); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", s.query, err))
 		}
-		delete(sc.cache, query)
 	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close %d statements: %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Len returns number of cached Stmts.
+func (sc *stmtCacher) Len() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.lru.Len()
+}
+
+// Queries returns queries of cached Stmts, most recently used first.
+func (sc *stmtCacher) Queries() []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	queries := make([]string, 0, sc.lru.Len())
+	for e := sc.lru.Front(); e != nil; e = e.Next() {
+		queries = append(queries, e.Value.(*savedStmt).query)
+	}
+	return queries
 }
 
+// PrepareContext returns the cached Stmt for the query, preparing it on a miss.
+// The Stmt is owned by the cache and gets closed once it is evicted, use
+// ExecContext and friends to run queries safely while other ones are cached.
 func (sc *stmtCacher) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	s, err := sc.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	sc.release(s)
+	return s.stmt, nil
+}
+
+// acquire returns the cached Stmt for the query, preparing it on a miss.
+// The Stmt is not closed by eviction until the caller releases it.
+func (sc *stmtCacher) acquire(ctx context.Context, query string) (*savedStmt, error) {
+	s, hit, err := sc.prepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// hooks are called without sc.mu held, so they are free to use the cache
+	if hit && sc.onHit != nil {
+		sc.onHit(query)
+	} else if !hit && sc.onMiss != nil {
+		sc.onMiss(query)
+	}
+	return s, nil
+}
+
+func (sc *stmtCacher) prepareContext(ctx context.Context, query string) (s *savedStmt, hit bool, err error) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 
+	if sc.closed {
+		return nil, false, ErrStmtCacheClosed
+	}
+
 	if s, ok := sc.cache[query]; ok {
-		if !s.expiration.Stop() {
-			<-s.expiration.C
-		}
-		s.expiration.Reset(maxAge)
-		return s.stmt, nil
+		s.lastUsed = time.Now()
+		s.refs++
+		sc.lru.MoveToFront(s.elem)
+		return s, true, nil
 	}
 	stmt, err := sc.prep.PrepareContext(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	if sc.maxEntries > 0 && sc.lru.Len() >= sc.maxEntries {
+		sc.evict(sc.lru.Back().Value.(*savedStmt))
 	}
 
-	sc.cache[query] = &savedStmt{
-		stmt:       stmt,
-		expiration: time.AfterFunc(maxAge, sc.remove(query)),
+	s = &savedStmt{query: query, stmt: stmt, lastUsed: time.Now(), refs: 1}
+	if sc.ttl > 0 {
+		s.expiration = time.AfterFunc(sc.ttl, func() { sc.expire(s) })
 	}
+	s.elem = sc.lru.PushFront(s)
+	sc.cache[query] = s
+
+	return s, false, nil
+}
+
+type noStmtCacheKey struct{}
 
-	return stmt, nil
+// WithoutStmtCache returns a context making statement caches created by
+// NewStmtCacher and friends bypass the cache for queries run with it.
+// Such queries are prepared and the Stmt is closed right after running them,
+// e.g. for rarely repeated queries that are costly to keep prepared.
+// Cache hooks are not called for bypassed queries.
+//
+// Ex:
+//     Select("*").From("report").RunWith(cache).QueryContext(WithoutStmtCache(ctx))
+func WithoutStmtCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noStmtCacheKey{}, true)
+}
+
+func bypassStmtCache(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noStmtCacheKey{}).(bool)
+	return bypass
+}
+
+// prepareStmtContext prepares the query using the cache unless it is bypassed
+// by ctx. The caller has to call done once it does not use the Stmt anymore,
+// which closes Stmts prepared without the cache.
+func (sc *stmtCacher) prepareStmtContext(ctx context.Context, query string) (stmt *sql.Stmt, done func(), err error) {
+	if !bypassStmtCache(ctx) {
+		s, err := sc.acquire(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.stmt, func() { sc.release(s) }, nil
+	}
+
+	sc.mu.Lock()
+	closed := sc.closed
+	sc.mu.Unlock()
+	if closed {
+		return nil, nil, ErrStmtCacheClosed
+	}
+
+	stmt, err = sc.prep.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stmt, func() { stmt.Close() }, nil
 }
 
 func (sc *stmtCacher) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
-	stmt, err := sc.PrepareContext(ctx, query)
+	stmt, done, err := sc.prepareStmtContext(ctx, query)
 	if err != nil {
 		return
 	}
+	defer done()
 	return stmt.ExecContext(ctx, args...)
 }
 
+// QueryContext runs the query with a prepared Stmt, which is released once
+// the rows are closed. Stmts prepared in a transaction close their rows when
+// closed, so they cannot be released right away.
 func (sc *stmtCacher) QueryContext(ctx context.Context, query string, args ...interface{}) (rows RowsScanner, err error) {
-	stmt, err := sc.PrepareContext(ctx, query)
+	stmt, done, err := sc.prepareStmtContext(ctx, query)
 	if err != nil {
 		return
 	}
-	return stmt.QueryContext(ctx, args...)
+	sqlRows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	return &stmtRows{Rows: sqlRows, done: done}, nil
 }
 
+// QueryRowContext runs the query with a prepared Stmt, which is released
+// once the row is scanned. See QueryContext.
 func (sc *stmtCacher) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
-	stmt, err := sc.PrepareContext(ctx, query)
+	stmt, done, err := sc.prepareStmtContext(ctx, query)
 	if err != nil {
 		return &Row{err: err}
 	}
-	return stmt.QueryRowContext(ctx, args...)
+	return &stmtRow{Row: stmt.QueryRowContext(ctx, args...), done: done}
+}
+
+// stmtRows releases the Stmt the rows are read from once they are closed
+type stmtRows struct {
+	*sql.Rows
+	done func()
+	once sync.Once
+}
+
+func (r *stmtRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	// database/sql closes the rows once they are exhausted
+	r.once.Do(r.done)
+	return false
+}
+
+func (r *stmtRows) Close() error {
+	err := r.Rows.Close()
+	r.once.Do(r.done)
+	return err
+}
+
+// stmtRow releases the Stmt the row is read from once it is scanned
+type stmtRow struct {
+	*sql.Row
+	done func()
+	once sync.Once
+}
+
+func (r *stmtRow) Scan(dest ...interface{}) error {
+	defer r.once.Do(r.done)
+	return r.Row.Scan(dest...)
 }
 
 func (sc *stmtCacher) Prepare(query string) (*sql.Stmt, error) {
@@ -128,16 +399,69 @@ type DBProxyBeginner interface {
 	Begin() (*sql.Tx, error)
 }
 
-type stmtCacheProxy struct {
+// DBProxyTxBeginner describes a DBProxy that can start transactions with
+// their own DBProxy. DBProxyBeginner may implement it as well.
+type DBProxyTxBeginner interface {
+	DBProxy
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (DBProxyTx, error)
+}
+
+// DBProxyTx describes a DBProxy bound to a transaction
+type DBProxyTx interface {
 	DBProxy
+	Commit() error
+	Rollback() error
+}
+
+// StmtCacheProxy is a DBProxyBeginner caching Prepared Stmts like StmtCache.
+// Close closes all cached Stmts, e.g. on shutdown.
+type StmtCacheProxy interface {
+	DBProxyBeginner
+	DBProxyTxBeginner
+	StmtCache
+}
+
+type stmtCacheProxy struct {
+	StmtCache
 	db *sql.DB
 }
 
 // NewStmtCacheProxy creates new cache proxy for statements
-func NewStmtCacheProxy(db *sql.DB) DBProxyBeginner {
-	return &stmtCacheProxy{DBProxy: NewStmtCacher(db), db: db}
+func NewStmtCacheProxy(db *sql.DB) StmtCacheProxy {
+	return &stmtCacheProxy{StmtCache: NewStmtCacher(db), db: db}
 }
 
 func (sp *stmtCacheProxy) Begin() (*sql.Tx, error) {
 	return sp.db.Begin()
 }
+
+// BeginTx starts a transaction with its own statement cache.
+// Cached statements are closed when the transaction is committed or rolled back.
+func (sp *stmtCacheProxy) BeginTx(ctx context.Context, opts *sql.TxOptions) (DBProxyTx, error) {
+	tx, err := sp.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &stmtCacheTxProxy{StmtCache: NewStmtCacherWithTTL(tx, 0), tx: tx}, nil
+}
+
+type stmtCacheTxProxy struct {
+	StmtCache
+	tx *sql.Tx
+}
+
+func (tp *stmtCacheTxProxy) Commit() error {
+	err := tp.tx.Commit()
+	if closeErr := tp.StmtCache.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (tp *stmtCacheTxProxy) Rollback() error {
+	err := tp.tx.Rollback()
+	if closeErr := tp.StmtCache.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -1,7 +1,13 @@
 package sqrl
 
 import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,3 +23,343 @@ func TestStmtCacherPrepare(t *testing.T) {
 	sc.Prepare(query)
 	assert.Equal(t, 1, db.PrepareCount, "expected 1 Prepare, got %d", db.PrepareCount)
 }
+
+func TestStmtCacherLRU(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherLRU(db, 2)
+
+	_, err := sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+	_, err = sc.Prepare("SELECT 2")
+	assert.NoError(t, err)
+	_, err = sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+	assert.Empty(t, d.Closed())
+
+	_, err = sc.Prepare("SELECT 3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SELECT 2"}, d.Closed())
+
+	_, err = sc.Prepare("SELECT 2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SELECT 2", "SELECT 1"}, d.Closed())
+	assert.Equal(t, []string{"SELECT 1", "SELECT 2", "SELECT 3", "SELECT 2"}, d.Prepared())
+}
+
+func TestStmtCacherLRUConcurrent(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+	// yielding in the hook lets other goroutines evict the Stmt before it is used
+	sc := NewStmtCacherLRU(db, 1, WithCacheHooks(func(string) { runtime.Gosched() }, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				// every query evicts the Stmt another goroutine may be running
+				query := fmt.Sprintf("SELECT %d", (i+j)%3)
+				_, err := sc.Exec(query)
+				assert.NoError(t, err)
+
+				rows, err := sc.Query(query)
+				if assert.NoError(t, err) {
+					assert.NoError(t, rows.Close())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, sc.Len())
+	assert.NoError(t, sc.Close())
+}
+
+func TestStmtCacherTTL(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherWithTTL(db, 10*time.Millisecond)
+
+	_, err := sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+
+	deadline := time.Now().Add(time.Second)
+	for len(d.Closed()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, []string{"SELECT 1"}, d.Closed())
+
+	_, err = sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SELECT 1", "SELECT 1"}, d.Prepared())
+}
+
+func TestStmtCacherNoTTL(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherWithTTL(db, 0)
+
+	_, err := sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+	_, err = sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"SELECT 1"}, d.Prepared())
+	assert.Empty(t, d.Closed())
+}
+
+func TestStmtCacherClose(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherWithTTL(db, 10*time.Millisecond)
+
+	_, err := sc.Prepare("SELECT 1")
+	assert.NoError(t, err)
+	_, err = sc.Prepare("SELECT 2")
+	assert.NoError(t, err)
+
+	assert.NoError(t, sc.Close())
+	assert.ElementsMatch(t, []string{"SELECT 1", "SELECT 2"}, d.Closed())
+	assert.Equal(t, 0, sc.Len())
+
+	// stopped expiration must not close the statements again
+	time.Sleep(20 * time.Millisecond)
+	assert.ElementsMatch(t, []string{"SELECT 1", "SELECT 2"}, d.Closed())
+
+	_, err = sc.Prepare("SELECT 1")
+	assert.Equal(t, ErrStmtCacheClosed, err)
+}
+
+func TestStmtCacheProxyClose(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	sp := NewStmtCacheProxy(db)
+
+	_, err := sp.Exec("UPDATE a SET b = ?", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"UPDATE a SET b = ?"}, sp.Queries())
+
+	assert.NoError(t, sp.Close())
+	assert.Equal(t, []string{"UPDATE a SET b = ?"}, d.Closed())
+
+	_, err = sp.Exec("UPDATE a SET b = ?", 2)
+	assert.Equal(t, ErrStmtCacheClosed, err)
+}
+
+func TestStmtCacherConcurrentExpiry(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherWithTTL(db, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				_, err := sc.PrepareContext(context.Background(), "SELECT 1")
+				assert.NoError(t, err)
+				if j%20 == 0 {
+					time.Sleep(2 * time.Millisecond)
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("concurrent PrepareContext with expiring statements did not finish")
+	}
+}
+
+func TestStmtCacheProxyBeginTx(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	var sp DBProxyBeginner = NewStmtCacheProxy(db)
+	txb, ok := sp.(DBProxyTxBeginner)
+	if !assert.True(t, ok, "expected DBProxyTxBeginner") {
+		return
+	}
+
+	tx, err := txb.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = tx.Exec("UPDATE a SET b = ?", i)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"UPDATE a SET b = ?"}, d.Prepared())
+
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"UPDATE a SET b = ?"}, d.Closed())
+
+	_, err = tx.Exec("UPDATE a SET b = ?", 4)
+	assert.Equal(t, ErrStmtCacheClosed, err)
+
+	tx, err = txb.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = tx.Exec("UPDATE a SET b = ?", 5)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, []string{"UPDATE a SET b = ?", "UPDATE a SET b = ?"}, d.Closed())
+}
+
+func TestStmtCacherHooks(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+
+	var hits, misses []string
+	var sc StmtCache
+	sc = NewStmtCacher(db, WithCacheHooks(
+		func(query string) {
+			hits = append(hits, query)
+		},
+		func(query string) {
+			misses = append(misses, query)
+			if query == "SELECT 2" {
+				// hooks run outside of the lock, so using the cache must not deadlock
+				sc.Prepare("SELECT 1")
+			}
+		},
+	))
+
+	for _, query := range []string{"SELECT 1", "SELECT 2", "SELECT 1", "SELECT 1"} {
+		_, err := sc.Prepare(query)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"SELECT 1", "SELECT 1", "SELECT 1"}, hits)
+	assert.Equal(t, []string{"SELECT 1", "SELECT 2"}, misses)
+}
+
+func TestStmtCacherHooksPrepareError(t *testing.T) {
+	db, _ := newStubDB()
+	db.Close()
+
+	misses := 0
+	sc := NewStmtCacher(db, WithCacheHooks(nil, func(string) { misses++ }))
+
+	_, err := sc.Prepare("SELECT 1")
+	assert.Error(t, err)
+	_, err = sc.Exec("SELECT 1")
+	assert.Error(t, err)
+	assert.Equal(t, 0, misses)
+	assert.Equal(t, 0, sc.Len())
+}
+
+func TestStmtCacherLenQueries(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+	sc := NewStmtCacherWithTTL(db, 20*time.Millisecond)
+
+	assert.Equal(t, 0, sc.Len())
+	assert.Empty(t, sc.Queries())
+
+	sc.Prepare("SELECT 1")
+	sc.Prepare("SELECT 2")
+	sc.Prepare("SELECT 1")
+	assert.Equal(t, 2, sc.Len())
+
+	queries := sc.Queries()
+	assert.Equal(t, []string{"SELECT 1", "SELECT 2"}, queries)
+	queries[0] = "DROP TABLE users"
+	assert.Equal(t, []string{"SELECT 1", "SELECT 2"}, sc.Queries())
+
+	deadline := time.Now().Add(time.Second)
+	for sc.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 0, sc.Len())
+}
+
+func TestStmtCacherRaw(t *testing.T) {
+	db, _ := newStubDB()
+	defer db.Close()
+
+	hits := 0
+	sc := NewStmtCacher(db, WithCacheHooks(func(string) { hits++ }, nil))
+	defer sc.Close()
+
+	for i := 0; i < 2; i++ {
+		rows, err := QueryWith(sc, Raw("SELECT 1"))
+		assert.NoError(t, err)
+		assert.NoError(t, rows.Close())
+	}
+
+	_, err := ExecWith(sc, Raw("UPDATE t SET a = ?", 1))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, hits)
+	assert.ElementsMatch(t, []string{"SELECT 1", "UPDATE t SET a = ?"}, sc.Queries())
+}
+
+func TestStmtCacherWithoutStmtCache(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+
+	misses := 0
+	sc := NewStmtCacher(db, WithCacheHooks(nil, func(string) { misses++ }))
+	ctx := WithoutStmtCache(context.Background())
+
+	rows, err := QueryWithContext(ctx, sc, Select("1"))
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Close())
+
+	_, err = Update("t").Set("a", 1).RunWith(sc).ExecContext(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, sc.Len())
+	assert.Equal(t, 0, misses)
+	assert.Equal(t, []string{"SELECT 1", "UPDATE t SET a = ?"}, d.Prepared())
+	assert.Equal(t, []string{"SELECT 1", "UPDATE t SET a = ?"}, d.Closed())
+
+	_, err = ExecWithContext(context.Background(), sc, Update("t").Set("a", 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"UPDATE t SET a = ?"}, sc.Queries())
+	assert.Equal(t, 1, misses)
+
+	assert.NoError(t, sc.Close())
+	_, err = sc.ExecContext(ctx, "SELECT 1")
+	assert.Equal(t, ErrStmtCacheClosed, err)
+}
+
+func TestStmtCacherWithoutStmtCacheTx(t *testing.T) {
+	db, d := newStubDB()
+	defer db.Close()
+	d.columns = []string{"a"}
+	d.rows = [][]driver.Value{{int64(1)}}
+
+	tx, err := NewStmtCacheProxy(db).BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	ctx := WithoutStmtCache(context.Background())
+
+	rows, err := tx.QueryContext(ctx, "SELECT a")
+	assert.NoError(t, err)
+	assert.Empty(t, d.Closed())
+
+	var a int64
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Scan(&a))
+	assert.Equal(t, int64(1), a)
+	assert.False(t, rows.Next())
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []string{"SELECT a"}, d.Closed())
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, []string{"SELECT a"}, d.Closed())
+
+	row := tx.QueryRowContext(ctx, "SELECT a")
+	assert.Equal(t, []string{"SELECT a"}, d.Closed())
+	assert.NoError(t, row.Scan(&a))
+	assert.Equal(t, []string{"SELECT a", "SELECT a"}, d.Closed())
+
+	assert.NoError(t, tx.Commit())
+}
@@ -0,0 +1,126 @@
+package sqrl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structField maps a column name to the index path of a struct field
+type structField struct {
+	column string
+	index  []int
+}
+
+// structFields returns column mapped fields of struct type t in declaration order.
+//
+// Column name is taken from `db` tag, falling back to the field name.
+// Fields tagged with `db:"-"` and unexported fields are skipped,
+// untagged embedded structs are flattened except for pointers to unexported ones.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				if f.PkgPath != "" {
+					// pointers to unexported structs cannot be allocated
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, sf := range structFields(ft) {
+					sf.index = append([]int{i}, sf.index...)
+					fields = append(fields, sf)
+				}
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = f.Name
+		}
+		fields = append(fields, structField{column: column, index: f.Index})
+	}
+	return fields
+}
+
+// structFieldValue returns value of field at given index path,
+// nil pointers to embedded structs yield nil
+func structFieldValue(v reflect.Value, index []int) interface{} {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Interface()
+}
+
+// structRows converts slice of structs into values ordered by columns.
+// If columns is empty, it is derived from the first element.
+func structRows(structs interface{}, columns []string) ([]string, [][]interface{}, error) {
+	v := reflect.ValueOf(structs)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("expected slice of structs, got %T", structs)
+	}
+	if v.Len() == 0 {
+		return nil, nil, fmt.Errorf("expected non-empty slice of structs")
+	}
+
+	rows := make([][]interface{}, v.Len())
+	for r := 0; r < v.Len(); r++ {
+		elem := v.Index(r)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				return nil, nil, fmt.Errorf("row %d is nil", r)
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("row %d: expected struct, got %s", r, elem.Type())
+		}
+
+		fields := structFields(elem.Type())
+		if len(columns) == 0 {
+			for _, f := range fields {
+				columns = append(columns, f.column)
+			}
+			if len(columns) == 0 {
+				return nil, nil, fmt.Errorf("struct %s has no columns", elem.Type())
+			}
+		}
+
+		byColumn := make(map[string][]int, len(fields))
+		for _, f := range fields {
+			byColumn[f.column] = f.index
+		}
+
+		row := make([]interface{}, len(columns))
+		for c, column := range columns {
+			index, ok := byColumn[column]
+			if !ok {
+				return nil, nil, fmt.Errorf("row %d: struct %s has no field for column %q", r, elem.Type(), column)
+			}
+			row[c] = structFieldValue(elem, index)
+		}
+		rows[r] = row
+	}
+
+	return columns, rows, nil
+}
@@ -0,0 +1,116 @@
+package sqrl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// TruncateBuilder builds SQL TRUNCATE statements.
+type TruncateBuilder struct {
+	StatementBuilderType
+
+	tables   []string
+	identity string
+	behavior string
+}
+
+// NewTruncateBuilder creates new instance of TruncateBuilder
+func NewTruncateBuilder(b StatementBuilderType) *TruncateBuilder {
+	return &TruncateBuilder{StatementBuilderType: b}
+}
+
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+func (b *TruncateBuilder) Clone() *TruncateBuilder {
+	c := *b
+	c.tables = append([]string(nil), b.tables...)
+	return &c
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *TruncateBuilder) RunWith(runner BaseRunner) *TruncateBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *TruncateBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *TruncateBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *TruncateBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(b.runContext())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *TruncateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// ToSql builds the query into a SQL string. TRUNCATE statements have no args.
+func (b *TruncateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.tables) == 0 {
+		err = fmt.Errorf("truncate statements must specify at least one table")
+		return
+	}
+
+	sql := &bytes.Buffer{}
+
+	sql.WriteString("TRUNCATE TABLE ")
+	sql.WriteString(strings.Join(b.tables, ", "))
+
+	if len(b.identity) > 0 {
+		sql.WriteString(" ")
+		sql.WriteString(b.identity)
+	}
+
+	if len(b.behavior) > 0 {
+		sql.WriteString(" ")
+		sql.WriteString(b.behavior)
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Table adds tables to be truncated.
+func (b *TruncateBuilder) Table(tables ...string) *TruncateBuilder {
+	b.tables = append(b.tables, tables...)
+	return b
+}
+
+// RestartIdentity adds RESTART IDENTITY option resetting sequences owned by
+// columns of truncated tables.
+func (b *TruncateBuilder) RestartIdentity() *TruncateBuilder {
+	b.identity = "RESTART IDENTITY"
+	return b
+}
+
+// ContinueIdentity adds CONTINUE IDENTITY option keeping sequences unchanged.
+func (b *TruncateBuilder) ContinueIdentity() *TruncateBuilder {
+	b.identity = "CONTINUE IDENTITY"
+	return b
+}
+
+// Cascade adds CASCADE option truncating also tables referencing truncated
+// tables by foreign keys.
+func (b *TruncateBuilder) Cascade() *TruncateBuilder {
+	b.behavior = "CASCADE"
+	return b
+}
+
+// Restrict adds RESTRICT option refusing to truncate tables referenced by
+// foreign keys of other tables.
+func (b *TruncateBuilder) Restrict() *TruncateBuilder {
+	b.behavior = "RESTRICT"
+	return b
+}
@@ -0,0 +1,41 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateBuilderToSql(t *testing.T) {
+	sql, args, err := Truncate("a", "b").RestartIdentity().Cascade().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE a, b RESTART IDENTITY CASCADE", sql)
+	assert.Empty(t, args)
+
+	sql, _, err = Truncate("a").ContinueIdentity().Restrict().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE a CONTINUE IDENTITY RESTRICT", sql)
+
+	sql, _, err = Truncate().Table("a").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE a", sql)
+}
+
+func TestTruncateBuilderToSqlErr(t *testing.T) {
+	_, _, err := Truncate().ToSql()
+	assert.EqualError(t, err, "truncate statements must specify at least one table")
+}
+
+func TestTruncateBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	_, err := Truncate("a").Cascade().RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE a CASCADE", db.LastExecSql)
+
+	_, err = ExecWith(db, Truncate("b"))
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUNCATE TABLE b", db.LastExecSql)
+
+	_, err = Truncate("a").Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,47 @@ type setClause struct {
 	value  interface{}
 }
 
+// setClausesFromMap converts a map of column names and values to set clauses
+// ordered by column name.
+func setClausesFromMap(clauses map[string]interface{}) []setClause {
+	keys := make([]string, 0, len(clauses))
+	for key := range clauses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	setClauses := make([]setClause, len(keys))
+	for i, key := range keys {
+		setClauses[i] = setClause{column: key, value: clauses[key]}
+	}
+	return setClauses
+}
+
+// appendSetClausesToSql writes "column = value" assignments separated by commas.
+func appendSetClausesToSql(clauses []setClause, w io.Writer, args []interface{}) ([]interface{}, error) {
+	setSqls := make([]string, len(clauses))
+	for i, setClause := range clauses {
+		var valSql string
+		switch typedVal := setClause.value.(type) {
+		case Sqlizer:
+			var valArgs []interface{}
+			var err error
+			valSql, valArgs, err = nestedToSql(typedVal)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, valArgs...)
+		default:
+			valSql = "?"
+			args = append(args, typedVal)
+		}
+		setSqls[i] = fmt.Sprintf("%s = %s", setClause.column, valSql)
+	}
+
+	_, err := io.WriteString(w, strings.Join(setSqls, ", "))
+	return args, err
+}
+
 // Builder
 
 // UpdateBuilder builds SQL UPDATE statements.
@@ -23,7 +65,7 @@ type UpdateBuilder struct {
 
 	returning
 
-	prefixes   exprs
+	prefixes   []Sqlizer
 	table      string
 	fromParts  []Sqlizer
 	setClauses []setClause
@@ -35,7 +77,8 @@ type UpdateBuilder struct {
 	offset      uint64
 	offsetValid bool
 
-	suffixes exprs
+	suffixes   []Sqlizer
+	suffixArgs []interface{}
 }
 
 // NewUpdateBuilder creates new instance of UpdateBuilder
@@ -43,15 +86,40 @@ func NewUpdateBuilder(b StatementBuilderType) *UpdateBuilder {
 	return &UpdateBuilder{StatementBuilderType: b}
 }
 
+// Clone returns a copy of the builder, which can be changed without affecting
+// the original one.
+//
+// Clauses are copied, but their args and nested builders (e.g. subqueries)
+// are shared between the original and the copy.
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := *b
+	c.returning = append(returning(nil), b.returning...)
+	c.prefixes = append([]Sqlizer(nil), b.prefixes...)
+	c.fromParts = append([]Sqlizer(nil), b.fromParts...)
+	c.setClauses = append([]setClause(nil), b.setClauses...)
+	c.whereParts = append([]Sqlizer(nil), b.whereParts...)
+	c.orderBys = append([]string(nil), b.orderBys...)
+	c.suffixes = append([]Sqlizer(nil), b.suffixes...)
+	c.suffixArgs = append([]interface{}(nil), b.suffixArgs...)
+	return &c
+}
+
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b *UpdateBuilder) RunWith(runner BaseRunner) *UpdateBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
 
+// RunWithContext sets a Runner (like database/sql.DB) and a context to be used
+// with e.g. Exec. The context is used by methods that do not take one explicitly.
+func (b *UpdateBuilder) RunWithContext(ctx context.Context, runner BaseRunner) *UpdateBuilder {
+	b.ctx = ctx
+	return b.RunWith(runner)
+}
+
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b *UpdateBuilder) Exec() (sql.Result, error) {
-	return b.ExecContext(context.Background())
+	return b.ExecContext(b.runContext())
 }
 
 // ExecContext builds and Execs the query with the Runner set by RunWith using given context.
@@ -64,7 +132,7 @@ func (b *UpdateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *UpdateBuilder) Query() (RowsScanner, error) {
-	return b.QueryContext(context.Background())
+	return b.QueryContext(b.runContext())
 }
 
 // QueryContext builds and runs the query using given context and Query command.
@@ -77,7 +145,7 @@ func (b *UpdateBuilder) QueryContext(ctx context.Context) (RowsScanner, error) {
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b *UpdateBuilder) QueryRow() RowScanner {
-	return b.QueryRowContext(context.Background())
+	return b.QueryRowContext(b.runContext())
 }
 
 // QueryRowContext builds and runs the query using given context.
@@ -106,6 +174,17 @@ func (b *UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) *UpdateBuilder {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args, err = replacePlaceholdersArgs(b.placeholderFormat, sqlStr, args)
+	return
+}
+
+// toSqlRaw builds the query without replacing placeholders.
+func (b *UpdateBuilder) toSqlRaw() (sqlStr string, args []interface{}, err error) {
 	if len(b.table) == 0 {
 		err = fmt.Errorf("update statements must specify a table")
 		return
@@ -114,11 +193,18 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		err = fmt.Errorf("update statements must have at least one Set clause")
 		return
 	}
+	if b.fetchSyntax && (b.limitValid || b.offsetValid) {
+		err = fmt.Errorf("update statements cannot have LIMIT or OFFSET with OFFSET ... FETCH syntax")
+		return
+	}
 
 	sql := &bytes.Buffer{}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.prefixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
@@ -126,24 +212,10 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	sql.WriteString(b.table)
 
 	sql.WriteString(" SET ")
-	setSqls := make([]string, len(b.setClauses))
-	for i, setClause := range b.setClauses {
-		var valSql string
-		switch typedVal := setClause.value.(type) {
-		case Sqlizer:
-			var valArgs []interface{}
-			valSql, valArgs, err = typedVal.ToSql()
-			if err != nil {
-				return
-			}
-			args = append(args, valArgs...)
-		default:
-			valSql = "?"
-			args = append(args, typedVal)
-		}
-		setSqls[i] = fmt.Sprintf("%s = %s", setClause.column, valSql)
+	args, err = appendSetClausesToSql(b.setClauses, sql, args)
+	if err != nil {
+		return
 	}
-	sql.WriteString(strings.Join(setSqls, ", "))
 
 	if len(b.fromParts) > 0 {
 		sql.WriteString(" FROM ")
@@ -154,8 +226,7 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.whereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(b.whereParts, sql, " AND ", args)
+		args, err = appendClauseToSql(b.whereParts, sql, " WHERE ", " AND ", args)
 		if err != nil {
 			return
 		}
@@ -166,7 +237,6 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(strings.Join(b.orderBys, ", "))
 	}
 
-	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
 	if b.limitValid {
 		sql.WriteString(" LIMIT ")
 		sql.WriteString(strconv.FormatUint(b.limit, 10))
@@ -178,6 +248,9 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.returning) > 0 {
+		if err = b.checkPostgresClause("RETURNING"); err != nil {
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -186,10 +259,14 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = appendToSql(b.suffixes, sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
+	args = append(args, b.suffixArgs...)
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	sqlStr = sql.String()
 	return
 }
 
@@ -201,6 +278,13 @@ func (b *UpdateBuilder) Prefix(sql string, args ...interface{}) *UpdateBuilder {
 	return b
 }
 
+// PrefixExpr adds an expression built by expr to the beginning of the query.
+// Its args are placed before args of the query.
+func (b *UpdateBuilder) PrefixExpr(expr Sqlizer) *UpdateBuilder {
+	b.prefixes = append(b.prefixes, newPart(expr))
+	return b
+}
+
 // Table sets the table to be updateb.
 func (b *UpdateBuilder) Table(table string) *UpdateBuilder {
 	b.table = table
@@ -215,17 +299,7 @@ func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
 
 // SetMap is a convenience method which calls .Set for each key/value pair in clauses.
 func (b *UpdateBuilder) SetMap(clauses map[string]interface{}) *UpdateBuilder {
-	keys := make([]string, len(clauses))
-	i := 0
-	for key := range clauses {
-		keys[i] = key
-		i++
-	}
-	sort.Strings(keys)
-	for _, key := range keys {
-		val, _ := clauses[key]
-		b = b.Set(key, val)
-	}
+	b.setClauses = append(b.setClauses, setClausesFromMap(clauses)...)
 	return b
 }
 
@@ -294,8 +368,31 @@ func (b *UpdateBuilder) ReturningSelect(from *SelectBuilder, alias string) *Upda
 	return b
 }
 
+// ReturningExpr adds an expression with bound args to RETURNING clause of the query
+//
+// UPDATE ... RETURNING is PostgreSQL specific extension
+func (b *UpdateBuilder) ReturningExpr(expr interface{}, args ...interface{}) *UpdateBuilder {
+	b.returning.ReturningExpr(expr, args...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *UpdateBuilder) Suffix(sql string, args ...interface{}) *UpdateBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
 	return b
 }
+
+// SuffixExpr adds an expression built by expr to the end of the query.
+// Its args are placed after args of the query.
+func (b *UpdateBuilder) SuffixExpr(expr Sqlizer) *UpdateBuilder {
+	b.suffixes = append(b.suffixes, newPart(expr))
+	return b
+}
+
+// SuffixArgs appends args to the end of the query args without adding any SQL.
+// It is a low-level escape hatch for placeholders of a suffix composed in
+// pieces, args are placed after args of all other clauses including suffixes.
+func (b *UpdateBuilder) SuffixArgs(args ...interface{}) *UpdateBuilder {
+	b.suffixArgs = append(b.suffixArgs, args...)
+	return b
+}
@@ -46,6 +46,30 @@ func TestUpdateBuilderFrom(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 42}, args)
 }
 
+func TestUpdateBuilderFromSelect(t *testing.T) {
+	lookup := Select("id", "name").
+		From("categories").
+		Where(Eq{"active": true})
+
+	b := Update("products").
+		Set("category_name", Expr("c.name")).
+		Set("updated_by", "sync").
+		FromSelect(lookup, "c").
+		Where("products.category_id = c.id").
+		Where(Gt{"products.id": 100}).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql :=
+		"UPDATE products SET category_name = c.name, updated_by = $1 " +
+			"FROM (SELECT id, name FROM categories WHERE active = $2) AS c " +
+			"WHERE products.category_id = c.id AND products.id > $3"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"sync", true, 100}, args)
+}
+
 func TestUpdateBuilderReturning(t *testing.T) {
 	b := Update("a").
 		Set("foo", 1).
@@ -102,6 +126,20 @@ func TestUpdateBuilderPlaceholders(t *testing.T) {
 	assert.Equal(t, "UPDATE test SET x = $1, y = $2", sql)
 }
 
+func TestUpdateBuilderSetMapSorted(t *testing.T) {
+	clauses := map[string]interface{}{"c": 3, "a": 1, "d": 4, "b": 2}
+
+	sql, args, err := Update("test").SetMap(clauses).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE test SET a = ?, b = ?, c = ?, d = ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	for i := 0; i < 10; i++ {
+		otherSql, _, _ := Update("test").SetMap(clauses).ToSql()
+		assert.Equal(t, sql, otherSql)
+	}
+}
+
 func TestUpdateBuilderRunners(t *testing.T) {
 	db := &DBStub{}
 	b := Update("test").Set("x", 1).Suffix("RETURNING y").RunWith(db)
@@ -148,3 +186,47 @@ func TestUpdateBuilderNoRunner(t *testing.T) {
 	err = b.Scan()
 	assert.Equal(t, ErrRunnerNotSet, err)
 }
+
+func TestUpdateBuilderReturningExpr(t *testing.T) {
+	b := Update("a").
+		Set("foo", 1).
+		Where("id = ?", 42).
+		ReturningExpr("*")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET foo = ? WHERE id = ? RETURNING *", sql)
+	assert.Equal(t, []interface{}{1, 42}, args)
+}
+
+func TestUpdateBuilderOrderByLimit(t *testing.T) {
+	b := Update("jobs").
+		Set("status", "claimed").
+		Where(Eq{"status": "pending"}).
+		OrderBy("created_at ASC").
+		Limit(1)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE jobs SET status = ? WHERE status = ? ORDER BY created_at ASC LIMIT 1", sql)
+	assert.Equal(t, []interface{}{"claimed", "pending"}, args)
+
+	sql, _, err = Update("jobs").Set("status", "claimed").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE jobs SET status = ?", sql)
+}
+
+func TestUpdateBuilderClone(t *testing.T) {
+	base := Update("users").Set("name", "foo").Where("id = ?", 1)
+	clone := base.Clone().Set("age", 30).Where("active = ?", true)
+
+	sql, args, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ? WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{"foo", 1}, args)
+
+	sql, args, err = clone.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, age = ? WHERE id = ? AND active = ?", sql)
+	assert.Equal(t, []interface{}{"foo", 30, 1, true}, args)
+}
@@ -0,0 +1,106 @@
+package sqrl
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// OnConflictBuilder builds ON CONFLICT clause of InsertBuilder.
+//
+// INSERT ... ON CONFLICT is PostgreSQL specific extension
+type OnConflictBuilder struct {
+	insert *InsertBuilder
+
+	target     string
+	action     string
+	setClauses []setClause
+}
+
+// OnConflict starts ON CONFLICT clause of the query with given conflict target
+// columns. Target may be omitted for DO NOTHING.
+//
+// The clause has to be completed with DoNothing or DoUpdate.
+func (b *InsertBuilder) OnConflict(columns ...string) *OnConflictBuilder {
+	c := &OnConflictBuilder{insert: b}
+	if len(columns) > 0 {
+		c.target = "(" + strings.Join(columns, ", ") + ")"
+	}
+
+	b.onConflict = c
+	return c
+}
+
+// OnConflictConstraint starts ON CONFLICT ON CONSTRAINT clause of the query.
+//
+// The clause has to be completed with DoNothing or DoUpdate.
+func (b *InsertBuilder) OnConflictConstraint(name string) *OnConflictBuilder {
+	c := &OnConflictBuilder{insert: b, target: "ON CONSTRAINT " + name}
+	b.onConflict = c
+	return c
+}
+
+// DoNothing completes the clause with DO NOTHING action.
+func (c *OnConflictBuilder) DoNothing() *InsertBuilder {
+	c.action = "NOTHING"
+	c.setClauses = nil
+	return c.insert
+}
+
+// DoUpdate completes the clause with DO UPDATE SET action assigning values of
+// the map to columns in key order. Use Excluded to refer to the values
+// proposed for insertion.
+//
+// Ex:
+//     .OnConflict("id").DoUpdate(Eq{"name": Excluded("name")})
+func (c *OnConflictBuilder) DoUpdate(clauses map[string]interface{}) *InsertBuilder {
+	c.action = "UPDATE"
+	c.setClauses = setClausesFromMap(clauses)
+	return c.insert
+}
+
+func (c *OnConflictBuilder) appendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	io.WriteString(w, " ON CONFLICT ")
+	if len(c.target) > 0 {
+		io.WriteString(w, c.target)
+		io.WriteString(w, " ")
+	}
+
+	switch c.action {
+	case "NOTHING":
+		io.WriteString(w, "DO NOTHING")
+	case "UPDATE":
+		if len(c.setClauses) == 0 {
+			return nil, errors.New("on conflict do update clause must have at least one column to set")
+		}
+		io.WriteString(w, "DO UPDATE SET ")
+		return appendSetClausesToSql(c.setClauses, w, args)
+	default:
+		return nil, errors.New("on conflict clause must be completed with DoNothing or DoUpdate")
+	}
+
+	return args, nil
+}
+
+// OnDuplicateKeyUpdate adds ON DUPLICATE KEY UPDATE clause to the query
+// assigning values of the map to columns in key order. Values may refer to the
+// inserted values with Expr("VALUES(column)").
+//
+// INSERT ... ON DUPLICATE KEY UPDATE is MySQL specific extension
+func (b *InsertBuilder) OnDuplicateKeyUpdate(clauses map[string]interface{}) *InsertBuilder {
+	b.duplicateKeyUpdates = append(b.duplicateKeyUpdates, setClausesFromMap(clauses)...)
+	return b
+}
+
+// Excluded refers to the value of column proposed for insertion in
+// ON CONFLICT ... DO UPDATE clause, e.g. "EXCLUDED.column".
+func Excluded(column string) Sqlizer {
+	return excludedColumn(column)
+}
+
+// excludedColumn is a column of EXCLUDED row, written to SQL as is
+type excludedColumn string
+
+func (c excludedColumn) ToSql() (string, []interface{}, error) {
+	return "EXCLUDED." + string(c), nil, nil
+}
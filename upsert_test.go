@@ -0,0 +1,97 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertOnConflictDoNothing(t *testing.T) {
+	b := Insert("users").
+		Columns("id", "name").
+		Values(1, "moe").
+		OnConflict("id").DoNothing()
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (?,?) ON CONFLICT (id) DO NOTHING", sql)
+	assert.Equal(t, []interface{}{1, "moe"}, args)
+
+	b = Insert("users").Columns("id").Values(1).OnConflict().DoNothing()
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id) VALUES (?) ON CONFLICT DO NOTHING", sql)
+}
+
+func TestInsertOnConflictDoUpdate(t *testing.T) {
+	set := Eq{
+		"name":       Excluded("name"),
+		"visits":     Expr("users.visits + ?", 1),
+		"updated_by": "system",
+	}
+	b := Insert("users").
+		Columns("id", "name", "visits").
+		Values(1, "moe", 1).
+		OnConflict("id").DoUpdate(set).
+		Returning("id").
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "INSERT INTO users (id,name,visits) VALUES ($1,$2,$3) " +
+		"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, updated_by = $4, visits = users.visits + $5 " +
+		"RETURNING id"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, "moe", 1, "system", 1}, args)
+}
+
+func TestInsertOnConflictConstraint(t *testing.T) {
+	b := Insert("users").
+		Columns("email").
+		Values("moe@example.com").
+		OnConflictConstraint("users_email_key").DoUpdate(Eq{"email": Excluded("email")})
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (email) VALUES (?) "+
+		"ON CONFLICT ON CONSTRAINT users_email_key DO UPDATE SET email = EXCLUDED.email", sql)
+}
+
+func TestExcluded(t *testing.T) {
+	sql, args, err := Excluded(`"rate?"`).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `EXCLUDED."rate?"`, sql)
+	assert.Empty(t, args)
+}
+
+func TestInsertOnConflictErr(t *testing.T) {
+	b := Insert("users").Columns("id").Values(1)
+	b.OnConflict("id")
+
+	_, _, err := b.ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.OnConflict("id").DoUpdate(Eq{}).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertOnDuplicateKeyUpdate(t *testing.T) {
+	b := Insert("counters").
+		Columns("name", "hits").
+		Values("home", 1).
+		OnDuplicateKeyUpdate(Eq{
+			"hits":      Expr("hits + VALUES(hits)"),
+			"last_seen": Expr("NOW()"),
+			"source":    "web",
+		})
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "INSERT INTO counters (name,hits) VALUES (?,?) " +
+		"ON DUPLICATE KEY UPDATE hits = hits + VALUES(hits), last_seen = NOW(), source = ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"home", 1, "web"}, args)
+}
@@ -13,7 +13,7 @@ func (p wherePart) ToSql() (sql string, args []interface{}, err error) {
 	case nil:
 		// no-op
 	case Sqlizer:
-		return pred.ToSql()
+		return nestedToSql(pred)
 	case map[string]interface{}:
 		return Eq(pred).ToSql()
 	case string:
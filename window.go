@@ -0,0 +1,130 @@
+package sqrl
+
+import "bytes"
+
+// WindowBuilder builds window specifications for window function calls
+// ("fn OVER (...)") and for the WINDOW clause of SelectBuilder.
+type WindowBuilder struct {
+	function     Sqlizer
+	base         string
+	partitionBys []Sqlizer
+	orderBys     []Sqlizer
+	frame        Sqlizer
+}
+
+// Over returns a new WindowBuilder for window function call "function OVER (...)".
+//
+// Ex:
+//     .Column(Over("ROW_NUMBER()").PartitionBy("dept").OrderBy("salary DESC"))
+func Over(function interface{}, args ...interface{}) *WindowBuilder {
+	return &WindowBuilder{function: newPart(function, args...)}
+}
+
+// Window returns a new WindowBuilder for a window definition to be named
+// with SelectBuilder.Window.
+func Window() *WindowBuilder {
+	return &WindowBuilder{}
+}
+
+// ToSql implements Sqlizer
+func (w *WindowBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	sql := &bytes.Buffer{}
+
+	if w.function != nil {
+		var fnSql string
+		fnSql, args, err = w.function.ToSql()
+		if err != nil {
+			return
+		}
+
+		sql.WriteString(fnSql)
+		sql.WriteString(" OVER ")
+
+		// "fn OVER w" refers to a named window as is
+		if len(w.base) > 0 && len(w.partitionBys) == 0 && len(w.orderBys) == 0 && w.frame == nil {
+			sql.WriteString(w.base)
+			return sql.String(), args, nil
+		}
+	}
+
+	sql.WriteString("(")
+	args, err = w.appendSpecToSql(sql, args)
+	if err != nil {
+		return
+	}
+	sql.WriteString(")")
+
+	return sql.String(), args, nil
+}
+
+func (w *WindowBuilder) appendSpecToSql(sql *bytes.Buffer, args []interface{}) ([]interface{}, error) {
+	var err error
+	sep := ""
+
+	if len(w.base) > 0 {
+		sql.WriteString(w.base)
+		sep = " "
+	}
+
+	if len(w.partitionBys) > 0 {
+		sql.WriteString(sep)
+		sql.WriteString("PARTITION BY ")
+		args, err = appendToSql(w.partitionBys, sql, ", ", args)
+		if err != nil {
+			return nil, err
+		}
+		sep = " "
+	}
+
+	if len(w.orderBys) > 0 {
+		sql.WriteString(sep)
+		sql.WriteString("ORDER BY ")
+		args, err = appendToSql(w.orderBys, sql, ", ", args)
+		if err != nil {
+			return nil, err
+		}
+		sep = " "
+	}
+
+	if w.frame != nil {
+		sql.WriteString(sep)
+		args, err = appendToSql([]Sqlizer{w.frame}, sql, "", args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return args, nil
+}
+
+// Window makes the window refer to a window named with SelectBuilder.Window.
+func (w *WindowBuilder) Window(name string) *WindowBuilder {
+	w.base = name
+	return w
+}
+
+// PartitionBy adds a PARTITION BY expression to the window.
+func (w *WindowBuilder) PartitionBy(expr interface{}, args ...interface{}) *WindowBuilder {
+	w.partitionBys = append(w.partitionBys, newPart(expr, args...))
+	return w
+}
+
+// OrderBy adds an ORDER BY expression to the window.
+func (w *WindowBuilder) OrderBy(expr interface{}, args ...interface{}) *WindowBuilder {
+	w.orderBys = append(w.orderBys, newPart(expr, args...))
+	return w
+}
+
+// Rows sets a ROWS frame clause of the window, e.g.
+// Rows("BETWEEN ? PRECEDING AND CURRENT ROW", 3)
+func (w *WindowBuilder) Rows(frame string, args ...interface{}) *WindowBuilder {
+	w.frame = newPart("ROWS "+frame, args...)
+	return w
+}
+
+// Range sets a RANGE frame clause of the window, e.g.
+// Range("UNBOUNDED PRECEDING")
+func (w *WindowBuilder) Range(frame string, args ...interface{}) *WindowBuilder {
+	w.frame = newPart("RANGE "+frame, args...)
+	return w
+}
@@ -0,0 +1,64 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowEmpty(t *testing.T) {
+	sql, args, err := Over("ROW_NUMBER()").ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ROW_NUMBER() OVER ()", sql)
+	assert.Empty(t, args)
+}
+
+func TestWindowFullySpecified(t *testing.T) {
+	w := Over("SUM(amount)").
+		PartitionBy("account_id").
+		PartitionBy("date_trunc(?, created_at)", "month").
+		OrderBy("created_at").
+		Rows("BETWEEN ? PRECEDING AND CURRENT ROW", 3)
+
+	qb := Select("id").
+		Column(Alias(w, "running_total")).
+		From("payments").
+		Where("amount > ?", 0)
+	sql, args, err := qb.ToSql()
+
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id, (SUM(amount) OVER (" +
+		"PARTITION BY account_id, date_trunc(?, created_at) " +
+		"ORDER BY created_at " +
+		"ROWS BETWEEN ? PRECEDING AND CURRENT ROW" +
+		")) AS running_total " +
+		"FROM payments WHERE amount > ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"month", 3, 0}, args)
+}
+
+func TestWindowNamed(t *testing.T) {
+	qb := Select("id").
+		Column(Over("RANK()").Window("w")).
+		Column(Over("SUM(amount)").Window("w").Range("UNBOUNDED PRECEDING")).
+		From("payments").
+		Window("w", Window().PartitionBy("account_id").OrderBy("amount DESC"))
+	sql, args, err := qb.ToSql()
+
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id, RANK() OVER w, SUM(amount) OVER (w RANGE UNBOUNDED PRECEDING) " +
+		"FROM payments " +
+		"WINDOW w AS (PARTITION BY account_id ORDER BY amount DESC)"
+	assert.Equal(t, expectedSql, sql)
+	assert.Empty(t, args)
+}
+
+func TestWindowNamedPercent(t *testing.T) {
+	sql, _, err := Select("id").From("t").Window("w", Window().PartitionBy("id % 10")).ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WINDOW w AS (PARTITION BY id % 10)", sql)
+}